@@ -3,6 +3,8 @@
 // 该包封装了与应用管理相关的所有API，包括：
 // - 累积运行结果查询
 // - 应用日志看板获取
+// - 应用配置查询
+// - 应用及文件夹列表查询
 //
 // 所有API均需要通过FastGPT客户端实例访问，使用前需先创建客户端。
 package app
@@ -18,14 +20,14 @@ import (
 //
 // 该结构体通过组合HTTP客户端，提供了与FastGPT应用管理相关的所有功能。
 type AppAPI struct {
-	client *client.Client // HTTP客户端，用于发送API请求
+	client client.Doer // HTTP客户端，用于发送API请求
 }
 
 // NewAppAPI 创建应用接口实例
 //
 // 参数：
 //
-//	c: HTTP客户端实例，由外部传入
+//	c: HTTP客户端实例，由外部传入，测试时可传入clienttest.Fake
 //
 // 返回值：
 //
@@ -35,13 +37,14 @@ type AppAPI struct {
 //
 //	c := client.NewClient("https://cloud.fastgpt.cn", "sk-xxx")
 //	appAPI := app.NewAppAPI(c)
-func NewAppAPI(c *client.Client) *AppAPI {
+func NewAppAPI(c client.Doer) *AppAPI {
 	return &AppAPI{client: c}
 }
 
 // GetTotalData 获取累积运行结果
 //
 // 该方法用于获取应用的累积运行结果，包括累积使用用户数量、累积对话数量和累积积分消耗。
+// 该接口仅商业版提供，社区版部署会返回包装了client.ErrProFeatureRequired的错误。
 //
 // 参数：
 //
@@ -79,6 +82,7 @@ func (api *AppAPI) GetTotalData(req *model.AppTotalDataRequest) (*model.AppTotal
 // GetChartData 获取应用日志看板
 //
 // 该方法用于获取应用的日志看板数据，包括指定时间范围内的用户统计、对话统计和应用统计。
+// 该接口仅商业版提供，社区版部署会返回包装了client.ErrProFeatureRequired的错误。
 //
 // 参数：
 //
@@ -98,13 +102,20 @@ func (api *AppAPI) GetTotalData(req *model.AppTotalDataRequest) (*model.AppTotal
 //	    DateStart:      "2025-09-19T16:00:00.000Z",
 //	    DateEnd:        "2025-09-27T15:59:59.999Z",
 //	    Offset:         1,
-//	    Source:         []string{"test", "online", "share", "api", "cronJob", "team", "feishu", "official_account", "wecom", "mcp"},
+//	    Source:         model.AllSources(),
 //	    UserTimespan:   "day",
 //	    ChatTimespan:   "day",
 //	    AppTimespan:    "day",
 //	}
 //	resp, err := appAPI.GetChartData(req)
 func (api *AppAPI) GetChartData(req *model.AppChartDataRequest) (*model.AppChartDataResponse, error) {
+	// 在本地校验日志来源，避免拼写错误导致统计结果静默为空
+	for _, source := range req.Source {
+		if !source.IsValid() {
+			return nil, fmt.Errorf("未知的日志来源: %q", source)
+		}
+	}
+
 	// 发送HTTP请求到FastGPT服务器
 	resp, err := api.client.DoRequest("POST", "/api/proApi/core/app/logs/getChartData", req)
 	if err != nil {
@@ -119,3 +130,122 @@ func (api *AppAPI) GetChartData(req *model.AppChartDataRequest) (*model.AppChart
 
 	return &chartDataResp, nil // 返回日志看板数据
 }
+
+// GetAppConfig 获取应用配置
+//
+// 该方法用于在不创建对话的情况下读取应用的对话配置，包括全局变量、文件上传配置和欢迎语等，
+// 便于客户端在发送第一条消息前渲染变量表单和文件上传入口。
+//
+// 参数：
+//
+//	appId: 应用ID
+//
+// 返回值：
+//
+//	*model.ChatAppInfo: 应用信息，对话配置位于ChatConfig字段
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	appInfo, err := appAPI.GetAppConfig("your-app-id")
+//	if err == nil {
+//	    fmt.Println(appInfo.ChatConfig.WelcomeText)
+//	}
+func (api *AppAPI) GetAppConfig(appId string) (*model.ChatAppInfo, error) {
+	// 发送HTTP请求到FastGPT服务器
+	resp, err := api.client.DoRequest("GET", fmt.Sprintf("/api/core/app/detail?appId=%s", appId), nil)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	// 解析响应数据，应用详情中的name、intro、chatConfig等字段与ChatAppInfo一致
+	var appInfo model.ChatAppInfo
+	if err := api.client.ParseResponse(resp, &appInfo); err != nil {
+		return nil, err // 响应解析失败，返回错误
+	}
+
+	return &appInfo, nil // 返回应用配置
+}
+
+// maxAppFolderDepth 构建应用树时允许的最大文件夹深度，防止异常数据导致无限递归
+const maxAppFolderDepth = 32
+
+// ListApps 获取指定文件夹下的应用列表
+//
+// 返回文件夹下直接包含的应用和子文件夹，不会递归获取子文件夹的内容。
+//
+// 参数：
+//
+//	parentId: 父级文件夹ID，为空表示根目录
+//
+// 返回值：
+//
+//	[]model.AppListItem: 应用和文件夹列表，可通过IsFolder区分
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	apps, err := appAPI.ListApps("")
+//	for _, a := range apps {
+//	    fmt.Printf("%s (%s)\n", a.Name, a.Type)
+//	}
+func (api *AppAPI) ListApps(parentId string) ([]model.AppListItem, error) {
+	resp, err := api.client.DoRequest("POST", "/api/core/app/list", &model.AppListRequest{ParentId: parentId})
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	var apps []model.AppListItem
+	if err := api.client.ParseResponse(resp, &apps); err != nil {
+		return nil, err // 响应解析失败，返回错误
+	}
+
+	return apps, nil // 返回应用列表
+}
+
+// GetAppTree 获取按文件夹层级组织的全部应用
+//
+// 从根目录开始逐层调用ListApps，每个文件夹请求一次，适合在管理后台中展示或盘点团队的全部应用。
+//
+// 返回值：
+//
+//	[]*model.AppTreeNode: 根目录下的应用和文件夹，文件夹的Children为其下的内容
+//	error: 任一请求失败或文件夹层级超过32层时返回错误
+//
+// 使用示例：
+//
+//	tree, err := appAPI.GetAppTree()
+//	if err != nil {
+//	    return err
+//	}
+//	for _, node := range tree {
+//	    fmt.Printf("%s: %d个子项\n", node.Name, len(node.Children))
+//	}
+func (api *AppAPI) GetAppTree() ([]*model.AppTreeNode, error) {
+	return api.appSubtree("", 0)
+}
+
+// appSubtree 递归获取文件夹下的应用树
+func (api *AppAPI) appSubtree(parentId string, depth int) ([]*model.AppTreeNode, error) {
+	if depth >= maxAppFolderDepth {
+		return nil, fmt.Errorf("应用文件夹 %s 的层级超过 %d 层", parentId, maxAppFolderDepth)
+	}
+
+	apps, err := api.ListApps(parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.AppTreeNode, 0, len(apps))
+	for _, item := range apps {
+		node := &model.AppTreeNode{AppListItem: item}
+		if item.IsFolder() && item.ID != parentId {
+			node.Children, err = api.appSubtree(item.ID, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
@@ -12,9 +12,18 @@ package chat
 
 import (
 	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/xxjwxc/fastgpt/client"
 	"github.com/xxjwxc/fastgpt/model"
@@ -25,14 +34,16 @@ import (
 // 该结构体通过组合HTTP客户端，提供了与FastGPT对话交互相关的所有功能，
 // 主要用于发送对话请求并处理SSE流式响应。
 type ChatAPI struct {
-	client *client.Client // HTTP客户端，用于发送API请求
+	client          client.Doer   // HTTP客户端，用于发送API请求
+	chatIDGenerator func() string // 对话ID生成函数，为nil时生成UUID
 }
 
 // NewChatAPI 创建对话接口实例
 //
 // 参数：
 //
-//	c: HTTP客户端实例，由外部传入
+//	c: HTTP客户端实例，由外部传入，测试时可传入clienttest.Fake
+//	opts: 可选配置项，如WithChatIDGenerator
 //
 // 返回值：
 //
@@ -42,8 +53,81 @@ type ChatAPI struct {
 //
 //	c := client.NewClient("https://cloud.fastgpt.cn", "sk-xxx")
 //	chatAPI := chat.NewChatAPI(c)
-func NewChatAPI(c *client.Client) *ChatAPI {
-	return &ChatAPI{client: c}
+func NewChatAPI(c client.Doer, opts ...Option) *ChatAPI {
+	api := &ChatAPI{client: c}
+	for _, opt := range opts {
+		opt(api)
+	}
+	return api
+}
+
+// SSE事件类型
+const (
+	EventAnswer          = "answer"          // 模型生成的回答，data为model.AnswerEvent
+	EventFastAnswer      = "fastAnswer"      // 指定回复等即时/缓存回答，data为model.AnswerEvent，可与answer区分展示
+	EventFlowNodeStatus  = "flowNodeStatus"  // 节点运行状态，data为model.FlowNodeStatusEvent
+	EventFlowResponses   = "flowResponses"   // 节点运行详情，data为model.FlowResponsesEvent
+	EventInteractive     = "interactive"     // 交互节点，data为model.Interactive
+	EventToolCall        = "toolCall"        // 工具调用，data为原始JSON字符串
+	EventToolParams      = "toolParams"      // 工具参数，data为原始JSON字符串
+	EventToolResponse    = "toolResponse"    // 工具响应，data为原始JSON字符串
+	EventUpdateVariables = "updateVariables" // 更新变量，data为原始JSON字符串
+	EventError           = "error"           // 错误，data为原始JSON字符串
+	EventReconnect       = "reconnect"       // SDK生成的重连事件，data为重连序号，见WithStreamReconnect
+)
+
+// eventDecoders 通过RegisterEventDecoder注册的事件解码函数
+var (
+	eventDecodersMu sync.RWMutex
+	eventDecoders   = make(map[string]func([]byte) (interface{}, error))
+)
+
+// RegisterEventDecoder 注册自定义事件的解码函数
+//
+// 默认情况下，toolCall、toolParams、toolResponse、updateVariables、error以及SDK未识别的事件
+// 以原始JSON字符串传给handler。注册解码函数后，这些事件的data会先经过解码函数转换，
+// 便于处理nodeResponse等自定义或新增的事件类型。answer、flowNodeStatus等已有类型的事件不受影响。
+//
+// 参数：
+//
+//	name: 事件名称，如"nodeResponse"
+//	fn: 解码函数，参数为原始data，返回值作为handler的data参数；为nil时取消注册
+//
+// 注意事项：
+//   - 注册表是全局的，建议在程序初始化时注册；该函数可以并发调用
+//
+// 使用示例：
+//
+//	chat.RegisterEventDecoder("nodeResponse", func(data []byte) (interface{}, error) {
+//	    var resp model.FlowResponse
+//	    err := json.Unmarshal(data, &resp)
+//	    return resp, err
+//	})
+func RegisterEventDecoder(name string, fn func([]byte) (interface{}, error)) {
+	eventDecodersMu.Lock()
+	defer eventDecodersMu.Unlock()
+
+	if fn == nil {
+		delete(eventDecoders, name)
+		return
+	}
+	eventDecoders[name] = fn
+}
+
+// decodeRawEvent 使用注册的解码函数解码事件数据，未注册时返回原始字符串
+func decodeRawEvent(eventType, data string) (interface{}, error) {
+	eventDecodersMu.RLock()
+	fn, ok := eventDecoders[eventType]
+	eventDecodersMu.RUnlock()
+
+	if !ok {
+		return data, nil
+	}
+	v, err := fn([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("解码%s事件失败: %w", eventType, err)
+	}
+	return v, nil
 }
 
 // ChatEventHandler SSE事件处理函数类型
@@ -52,7 +136,7 @@ func NewChatAPI(c *client.Client) *ChatAPI {
 //
 // 参数：
 //
-//	eventType: 事件类型，如"flowNodeStatus"、"answer"、"flowResponses"等
+//	eventType: 事件类型，如EventFlowNodeStatus、EventAnswer、EventFastAnswer等，answer和fastAnswer会按原始名称区分传入
 //	data: 事件数据，根据事件类型不同，数据类型也不同
 //
 // 返回值：
@@ -60,6 +144,144 @@ func NewChatAPI(c *client.Client) *ChatAPI {
 //	error: 如果处理失败，返回错误信息，将终止整个对话流程
 type ChatEventHandler func(eventType string, data interface{}) error
 
+// ChatOption 对话请求的可选配置
+type ChatOption func(*chatOptions)
+
+// defaultConnectAttempts 建立流式连接的默认尝试次数
+const defaultConnectAttempts = 2
+
+// chatOptions 对话请求的配置
+type chatOptions struct {
+	maxReconnects   int                                           // 流中断后的最大重连次数
+	connectAttempts int                                           // 建立流式连接的最大尝试次数
+	onEvent         func(eventType string, elapsed time.Duration) // 每个事件的耗时回调
+	callOpts        []client.CallOption                           // 单次请求的配置
+	rawWriter       io.Writer                                     // 原始SSE数据的输出目标
+}
+
+// WithStreamReconnect 流式连接在[DONE]之前意外中断时自动重连
+//
+// 重连会使用相同的请求（包括ChatId）重新发送，FastGPT不支持从中断处续传，
+// 服务端会重新生成完整的回答，因此重连前会以EventReconnect事件通知handler，
+// data为本次重连的序号（从1开始），调用方应在收到该事件时清空已拼接或已渲染的内容。
+//
+// 参数：
+//
+//	maxRetries: 最大重连次数，0表示不重连
+//
+// 注意事项：
+//   - 仅对Stream为true的请求生效；请求发送失败、handler返回错误或ctx被取消时不会重连
+//   - 使用ChatId时，服务端可能已保存中断前的对话记录，重连后历史中可能出现重复的提问
+//
+// 使用示例：
+//
+//	err := chatAPI.Chat(req, func(eventType string, data interface{}) error {
+//	    if eventType == chat.EventReconnect {
+//	        answer.Reset() // 丢弃中断前的内容
+//	        return nil
+//	    }
+//	    // 处理其他事件
+//	    return nil
+//	}, chat.WithStreamReconnect(3))
+func WithStreamReconnect(maxRetries int) ChatOption {
+	return func(o *chatOptions) {
+		o.maxReconnects = maxRetries
+	}
+}
+
+// WithConnectAttempts 设置建立流式连接的最大尝试次数，默认为2
+//
+// 服务端负载较高时，TLS握手或发送请求阶段可能出现"connection reset by peer"，
+// 此时handler还未收到任何事件，重新发送请求是安全的。与WithStreamReconnect不同，
+// 一旦收到第一个事件就不再重试，不会出现回答被重新生成的情况。
+//
+// 参数：
+//
+//	attempts: 最大尝试次数（包含第一次），小于等于1表示不重试
+//
+// 注意事项：
+//   - 仅对Stream为true的请求生效，且只在连接被重置（ECONNRESET或EOF）时重试
+//   - 每次重连（见WithStreamReconnect）都会重新计算尝试次数
+//
+// 使用示例：
+//
+//	err := chatAPI.Chat(req, handler, chat.WithConnectAttempts(3))
+func WithConnectAttempts(attempts int) ChatOption {
+	return func(o *chatOptions) {
+		o.connectAttempts = attempts
+	}
+}
+
+// WithOnEvent 设置事件耗时回调
+//
+// 每收到一个SSE事件，在调用handler之前回调一次，elapsed为从发起对话到收到该事件的耗时，
+// 可用于统计首字延迟（第一个answer事件）、字间延迟和总时长。未设置时没有额外开销。
+//
+// 参数：
+//
+//	fn: 事件耗时回调，eventType为事件类型
+//
+// 使用示例：
+//
+//	var ttft time.Duration
+//	err := chatAPI.Chat(req, handler, chat.WithOnEvent(func(eventType string, elapsed time.Duration) {
+//	    if eventType == chat.EventAnswer && ttft == 0 {
+//	        ttft = elapsed
+//	    }
+//	}))
+func WithOnEvent(fn func(eventType string, elapsed time.Duration)) ChatOption {
+	return func(o *chatOptions) {
+		o.onEvent = fn
+	}
+}
+
+// WithCallOptions 为本次对话请求设置单次请求配置
+//
+// 例如为耗时较长的工作流单独设置超时时间，而不影响客户端的其他请求。
+// client.WithCallContext会替代ChatContext传入的ctx。
+//
+// 参数：
+//
+//	opts: 单次请求配置，如client.WithCallTimeout、client.WithCallHeader、client.WithCallContext
+//
+// 使用示例：
+//
+//	err := chatAPI.Chat(req, handler, chat.WithCallOptions(client.WithCallTimeout(5*time.Minute)))
+func WithCallOptions(opts ...client.CallOption) ChatOption {
+	return func(o *chatOptions) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
+}
+
+// WithRawSSEWriter 将原始SSE数据写入w，用于调试
+//
+// 读取到的SSE数据会在解析之前原样写入w（包括event、data行和空行），
+// 便于排查解析问题或服务端、代理的异常行为。写入失败会被忽略，不影响正常的事件处理。
+//
+// 参数：
+//
+//	w: 原始数据的输出目标，如os.Stderr或文件
+//
+// 使用示例：
+//
+//	err := chatAPI.Chat(req, handler, chat.WithRawSSEWriter(os.Stderr))
+func WithRawSSEWriter(w io.Writer) ChatOption {
+	return func(o *chatOptions) {
+		o.rawWriter = w
+	}
+}
+
+// ignoreErrorWriter 忽略写入错误的Writer，避免调试输出失败中断SSE流的读取
+type ignoreErrorWriter struct {
+	w io.Writer
+}
+
+// Write 写入数据并始终返回成功
+func (w ignoreErrorWriter) Write(p []byte) (int, error) {
+	w.w.Write(p)
+	return len(p), nil
+}
+
 // Chat 发送对话请求并处理SSE流式响应
 //
 // 该方法用于发送对话请求，并通过SSE（Server-Sent Events）协议接收实时响应。
@@ -69,6 +291,7 @@ type ChatEventHandler func(eventType string, data interface{}) error
 //
 //	req: 对话请求，包含应用ID、消息列表、模型配置等
 //	handler: SSE事件处理函数，用于处理接收到的各种事件
+//	opts: 可选配置项，如WithStreamReconnect
 //
 // 返回值：
 //
@@ -81,7 +304,7 @@ type ChatEventHandler func(eventType string, data interface{}) error
 //	req := &model.ChatRequest{
 //	    AppId: "your-app-id",
 //	    Messages: []model.Message{
-//	        {Role: "user", Content: "你好"},
+//	        {Role: "user", Content: model.TextContent("你好")},
 //	    },
 //	}
 //
@@ -103,23 +326,181 @@ type ChatEventHandler func(eventType string, data interface{}) error
 //	    }
 //	    return nil
 //	})
-func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error {
+func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler, opts ...ChatOption) error {
+	return api.ChatContext(context.Background(), req, handler, opts...)
+}
+
+// ChatContext 发送带上下文的对话请求并处理SSE流式响应
+//
+// 与Chat相同，但可以通过ctx控制超时和取消；取消ctx会关闭连接并返回ctx的错误。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求
+//	handler: SSE事件处理函数
+//	opts: 可选配置项，如WithStreamReconnect
+//
+// 返回值：
+//
+//	error: 如果请求失败、事件处理失败或ctx被取消，返回错误信息
+//
+// 注意事项：
+//   - FastGPT OpenAPI没有提供按chatId中止对话的接口，SDK因此不提供Abort方法
+//   - 取消ctx只会关闭连接；FastGPT工作流在检测到连接关闭后会停止执行后续节点，
+//     但已经发出的模型请求仍会完成并计费，实现"停止生成"按钮时应以此为准
+func (api *ChatAPI) ChatContext(ctx context.Context, req *model.ChatRequest, handler ChatEventHandler, opts ...ChatOption) error {
+	options := chatOptions{connectAttempts: defaultConnectAttempts}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx, cancel := client.ApplyCallOptions(ctx, options.callOpts...)
+	defer cancel()
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		var received, done, handlerFailed bool
+		wrapped := func(eventType string, data interface{}) error {
+			received = true
+			if options.onEvent != nil {
+				options.onEvent(eventType, time.Since(start))
+			}
+			if data == "[DONE]" {
+				done = true
+			}
+			if err := handler(eventType, data); err != nil {
+				handlerFailed = true
+				return err
+			}
+			return nil
+		}
+
+		// 收到第一个事件之前连接被重置时，直接重新发送请求
+		var err error
+		for try := 1; ; try++ {
+			err = api.chatOnce(ctx, req, options.rawWriter, wrapped)
+			if received || !req.Stream || ctx.Err() != nil || try >= options.connectAttempts || !isConnectionReset(err) {
+				break
+			}
+			api.debugf("fastgpt: retry %d/%d after connection reset: %v", try, options.connectAttempts-1, err)
+		}
+
+		// 正常结束、事件处理失败、非流式请求或ctx已取消时不重连
+		var reqErr *requestError
+		if done || handlerFailed || !req.Stream || ctx.Err() != nil || errors.As(err, &reqErr) ||
+			attempt >= options.maxReconnects {
+			if reqErr != nil {
+				return reqErr.err
+			}
+			return err
+		}
+
+		// 连接在[DONE]之前中断，通知调用方丢弃已渲染的内容后重新发送请求
+		api.debugf("fastgpt: reconnect %d/%d after stream interrupted: %v", attempt+1, options.maxReconnects, err)
+		if err := handler(EventReconnect, attempt+1); err != nil {
+			return err
+		}
+	}
+}
+
+// debugLogger 支持输出debug日志的客户端，*client.Client实现了该接口
+type debugLogger interface {
+	Debugf(format string, args ...interface{})
+}
+
+// debugf 客户端支持时输出debug日志，如重试和重连的原因
+func (api *ChatAPI) debugf(format string, args ...interface{}) {
+	if l, ok := api.client.(debugLogger); ok {
+		l.Debugf(format, args...)
+	}
+}
+
+// isConnectionReset 判断错误是否为连接被对端重置或提前关闭
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// requestError 发送请求阶段的错误，用于与流读取中断区分，不会触发重连
+type requestError struct {
+	err error
+}
+
+// Error 实现error接口
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回原始错误
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// chatOnce 发送一次对话请求并读取完整的SSE流
+//
+// rawWriter不为nil时，读取到的原始SSE数据会先写入rawWriter再解析。
+func (api *ChatAPI) chatOnce(ctx context.Context, req *model.ChatRequest, rawWriter io.Writer, handler ChatEventHandler) error {
 	// 发送对话请求到FastGPT服务器
-	resp, err := api.client.DoRequest("POST", "/api/v1/chat/completions", req)
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/v1/chat/completions", req)
 	if err != nil {
-		return err // 请求发送失败，返回错误
+		return &requestError{err: err} // 请求发送失败，返回错误
 	}
 	defer resp.Body.Close() // 确保响应体被关闭
 
-	// 创建扫描器，用于逐行读取SSE流
-	scanner := bufio.NewScanner(resp.Body)
+	// 非2xx响应（如鉴权失败、被重定向到登录页）和HTML页面（如跟随重定向后到达的登录页）不是SSE流，按普通响应解析出错误
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		if err := api.client.ParseResponse(resp, nil); err != nil {
+			return &requestError{err: err}
+		}
+		return &requestError{err: &client.APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.StatusCode,
+			Message:    resp.Status,
+		}}
+	}
+
+	// 设置了rawWriter时，原始SSE数据先写入rawWriter再交给ParseSSE解析
+	var body io.Reader = resp.Body
+	if rawWriter != nil {
+		body = io.TeeReader(resp.Body, ignoreErrorWriter{rawWriter})
+	}
+	return ParseSSE(body, handler)
+}
+
+// ParseSSE 从r中逐个解析SSE事件并调用handler
+//
+// 这是Chat内部使用的SSE解析逻辑，事件的解码方式与Chat完全相同
+// （包括RegisterEventDecoder注册的解码函数），可用于：
+//   - 使用抓取到的SSE数据（如WithRawSSEWriter保存的文件）测试事件处理逻辑，无需启动服务
+//   - 回放历史对话流进行调试
+//
+// 参数：
+//
+//	r: SSE数据源，如文件或strings.Reader
+//	handler: 事件处理函数，返回错误时立即停止解析
+//
+// 返回值：
+//
+//	error: 事件数据JSON解析失败、handler返回错误或读取失败时返回错误，读取到EOF时返回nil
+//
+// 使用示例：
+//
+//	f, _ := os.Open("testdata/chat.sse")
+//	defer f.Close()
+//	err := chat.ParseSSE(f, func(eventType string, data interface{}) error {
+//	    fmt.Println(eventType, data)
+//	    return nil
+//	})
+func ParseSSE(r io.Reader, handler ChatEventHandler) error {
+	scanner := bufio.NewScanner(r)
 
 	// 循环读取SSE流中的每一行，处理SSE事件
 	var currentEvent string // 当前事件名称，默认为"message"
 	var currentData []string // 当前事件的数据行
 
 	for scanner.Scan() {
-		line := scanner.Text()
+		// 部分反向代理使用CRLF换行，ScanLines只去掉\n，需要再去掉行尾的\r
+		line := strings.TrimSuffix(scanner.Text(), "\r")
 
 		// 空行表示当前事件结束，处理累积的事件数据
 		if line == "" {
@@ -130,7 +511,7 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 				
 				// 根据事件名称解析数据
 				switch currentEvent {
-				case "flowNodeStatus":
+				case EventFlowNodeStatus:
 					// 处理节点状态事件
 					var statusEvent model.FlowNodeStatusEvent
 					if err := json.Unmarshal([]byte(dataContent), &statusEvent); err != nil {
@@ -141,7 +522,7 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 						return err // 事件处理失败，返回错误
 					}
 
-				case "answer", "fastAnswer":
+				case EventAnswer, EventFastAnswer:
 					// 处理回答事件和快速回答事件
 					// 检查是否是对话结束标志
 					if dataContent == "[DONE]" {
@@ -164,7 +545,7 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 						return err // 事件处理失败，返回错误
 					}
 
-				case "flowResponses":
+				case EventFlowResponses:
 					// 处理流程响应事件
 					var flowEvent model.FlowResponsesEvent
 					if err := json.Unmarshal([]byte(dataContent), &flowEvent); err != nil {
@@ -175,14 +556,18 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 						return err // 事件处理失败，返回错误
 					}
 
-				case "toolCall", "toolParams", "toolResponse", "updateVariables", "error":
+				case EventToolCall, EventToolParams, EventToolResponse, EventUpdateVariables, EventError:
 					// 处理工具调用、工具参数、工具响应、更新变量和错误事件
-					// 这些事件直接传递原始数据，由调用者自行解析
-					if err := handler(currentEvent, dataContent); err != nil {
+					// 这些事件默认传递原始数据，由调用者自行解析或通过RegisterEventDecoder注册解码函数
+					eventData, err := decodeRawEvent(currentEvent, dataContent)
+					if err != nil {
+						return err // 自定义解码失败，返回错误
+					}
+					if err := handler(currentEvent, eventData); err != nil {
 						return err // 事件处理失败，返回错误
 					}
 
-				case "interactive":
+				case EventInteractive:
 					// 处理交互节点事件
 					var interactiveEvent model.Interactive
 					if err := json.Unmarshal([]byte(dataContent), &interactiveEvent); err != nil {
@@ -194,8 +579,12 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 					}
 
 				default:
-					// 处理未知事件类型，直接传递原始数据
-					if err := handler(currentEvent, dataContent); err != nil {
+					// 处理未知事件类型，默认传递原始数据
+					eventData, err := decodeRawEvent(currentEvent, dataContent)
+					if err != nil {
+						return err // 自定义解码失败，返回错误
+					}
+					if err := handler(currentEvent, eventData); err != nil {
 						return err // 事件处理失败，返回错误
 					}
 				}
@@ -250,6 +639,147 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 	return nil // 对话处理成功
 }
 
+// ChatWithResponseID 发送对话请求并返回完整回答及AI回复消息ID
+//
+// 该方法以流式方式发送请求，拼接answer和fastAnswer事件中的内容，
+// 并从流事件中读取服务端实际使用的AI回复消息ID（dataId）。
+// 未设置req.ResponseChatItemId时会自动生成一个，便于重试时复用同一ID；
+// 未设置req.ChatId时会使用NewChatID生成对话ID，并通过返回值中的ChatId返回。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream会被强制设置为true，不会修改调用方传入的请求
+//
+// 返回值：
+//
+//	*model.ChatResult: 对话结果，包含对话ID、AI回复消息ID和完整回答；出错时包含中断前已接收的部分回答
+//	error: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 注意事项：
+//
+//   - 流事件中带有消息ID时以服务端返回的ID为准，否则返回请求中预设的ID
+//   - 流在中途中断（如ctx超时）时，返回值中的result和error同时非空，result.Text为已接收的部分回答，
+//     界面可以先展示这部分内容再提示错误
+//
+// 使用示例：
+//
+//	result, err := chatAPI.ChatWithResponseID(ctx, req)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(result.Text)
+//	// 之后可以使用result.DataId调用UpdateUserFeedback
+func (api *ChatAPI) ChatWithResponseID(ctx context.Context, req *model.ChatRequest) (*model.ChatResult, error) {
+	return api.streamResult(ctx, req, nil)
+}
+
+// streamResult 以流式方式发送请求并汇总回答，回答以外的事件交给observe（可为nil）处理
+func (api *ChatAPI) streamResult(ctx context.Context, req *model.ChatRequest, observe ChatEventHandler) (*model.ChatResult, error) {
+	streamReq := *req
+	streamReq.Stream = true
+	if streamReq.ChatId == "" {
+		streamReq.ChatId = api.NewChatID() // 生成对话ID，便于调用方保存后继续对话或重试
+	}
+	if streamReq.ResponseChatItemId == "" {
+		id, err := newResponseChatItemId()
+		if err != nil {
+			return nil, err // 生成消息ID失败，返回错误
+		}
+		streamReq.ResponseChatItemId = id
+	}
+
+	result := &model.ChatResult{ChatId: streamReq.ChatId}
+	var text strings.Builder
+	err := api.ChatContext(ctx, &streamReq, func(eventType string, data interface{}) error {
+		if eventType == EventReconnect {
+			// 服务端会重新生成完整的回答，丢弃中断前已拼接的内容
+			text.Reset()
+			result.FastAnswer, result.FinishReason, result.Usage = false, "", nil
+		}
+		answerEvent, ok := data.(model.AnswerEvent)
+		if !ok {
+			if observe != nil {
+				return observe(eventType, data)
+			}
+			return nil // 忽略[DONE]和其他事件
+		}
+		if answerEvent.ID != "" {
+			result.DataId = answerEvent.ID // 以服务端返回的消息ID为准
+		}
+		if eventType == EventFastAnswer {
+			result.FastAnswer = true
+		}
+		for _, choice := range answerEvent.Choices {
+			text.WriteString(choice.Delta.Content)
+		}
+		if reason := answerEvent.FinishReason(); reason != "" {
+			result.FinishReason = reason
+		}
+		if answerEvent.Usage != nil {
+			result.Usage = answerEvent.Usage
+		}
+		return nil
+	})
+	if result.DataId == "" {
+		result.DataId = streamReq.ResponseChatItemId
+	}
+	result.Text = text.String()
+	if err != nil {
+		return result, err // 返回已接收的部分内容和错误
+	}
+
+	return result, nil
+}
+
+// ChatText 发送对话请求并返回完整的回答文本、token使用情况和结束原因
+//
+// 该方法是ChatWithResponseID的简化版本；需要消息ID或自动生成的对话ID时请使用ChatWithResponseID。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream会被强制设置为true，不会修改调用方传入的请求
+//
+// 返回值：
+//
+//	text: 回答文本；出错时为中断前已接收的部分回答，没有接收到内容时为空字符串
+//	usage: 回答块中返回的token使用情况，服务端未返回或在返回前中断时为nil
+//	finishReason: 最后一个回答块的结束原因，为model.FinishReasonLength时表示回答因长度限制被截断，
+//	    可以据此决定是否继续生成；在收到结束原因前中断时为空字符串
+//	err: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 注意事项：
+//   - 出错时text、usage、finishReason仍然有效，为中断前已接收的内容，界面可以先展示部分回答再提示错误
+//
+// 使用示例：
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	text, _, finishReason, err := chatAPI.ChatText(ctx, req)
+//	if err != nil && text != "" {
+//	    fmt.Println("回答未完成：", text)
+//	}
+//	if finishReason == model.FinishReasonLength {
+//	    // 回答被截断，可以继续生成
+//	}
+func (api *ChatAPI) ChatText(ctx context.Context, req *model.ChatRequest) (text string, usage *model.Usage, finishReason string, err error) {
+	result, err := api.ChatWithResponseID(ctx, req)
+	if result == nil {
+		return "", nil, "", err
+	}
+	return result.Text, result.Usage, result.FinishReason, err
+}
+
+// newResponseChatItemId 生成24位十六进制的响应消息ID
+func newResponseChatItemId() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成响应消息ID失败: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // GetHistories 获取应用历史记录
 //
 // 该方法用于获取应用的历史对话记录，支持分页查询。
@@ -266,7 +796,12 @@ func (api *ChatAPI) Chat(req *model.ChatRequest, handler ChatEventHandler) error
 //
 // 接口文档：https://doc.fastgpt.cn/docs/introduction/development/openapi/chat#%E8%8E%B7%E5%8F%96%E6%9F%90%E4%B8%AA%E5%BA%94%E7%94%A8%E5%8E%86%E5%8F%B2%E8%AE%B0%E5%BD%95
 func (api *ChatAPI) GetHistories(req *model.GetHistoriesRequest) (*model.GetHistoriesResponse, error) {
-	resp, err := api.client.DoRequest("POST", "/api/core/chat/getHistories", req)
+	return api.getHistories(context.Background(), req)
+}
+
+// getHistories 使用ctx获取应用历史记录
+func (api *ChatAPI) getHistories(ctx context.Context, req *model.GetHistoriesRequest) (*model.GetHistoriesResponse, error) {
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/core/chat/getHistories", req)
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +827,9 @@ func (api *ChatAPI) GetHistories(req *model.GetHistoriesRequest) (*model.GetHist
 //
 //	error: 如果请求失败，返回错误信息
 //
+// 注意事项：
+// - Top为nil时不会发送top字段，置顶状态保持不变；取消置顶必须传model.Bool(false)
+//
 // 接口文档：https://doc.fastgpt.cn/docs/introduction/development/openapi/chat#%E4%BF%AE%E6%94%B9%E6%9F%90%E4%B8%AA%E5%AF%B9%E8%AF%9D%E7%9A%84%E6%A0%87%E9%A2%98
 func (api *ChatAPI) UpdateHistory(req *model.UpdateHistoryRequest) error {
 	resp, err := api.client.DoRequest("POST", "/api/core/chat/updateHistory", req)
@@ -307,6 +845,29 @@ func (api *ChatAPI) UpdateHistory(req *model.UpdateHistoryRequest) error {
 	return nil
 }
 
+// PinHistory 置顶或取消置顶历史记录
+//
+// 该方法显式发送top字段，不会修改对话标题。
+//
+// 参数：
+//
+//	appId: 应用ID
+//
+//	chatId: 对话ID
+//
+//	top: true为置顶，false为取消置顶
+//
+// 返回值：
+//
+//	error: 如果请求失败，返回错误信息
+func (api *ChatAPI) PinHistory(appId, chatId string, top bool) error {
+	return api.UpdateHistory(&model.UpdateHistoryRequest{
+		AppId:  appId,
+		ChatId: chatId,
+		Top:    model.Bool(top),
+	})
+}
+
 // DeleteHistory 删除单个历史记录
 //
 // 该方法用于删除单个对话历史记录。
@@ -317,13 +878,26 @@ func (api *ChatAPI) UpdateHistory(req *model.UpdateHistoryRequest) error {
 //
 //	chatId: 对话ID
 //
+//	source: 可选，对话来源，如api；不传或为空时不限制来源
+//
 // 返回值：
 //
 //	error: 如果请求失败，返回错误信息
 //
 // 接口文档：https://doc.fastgpt.cn/docs/introduction/development/openapi/chat#%E5%88%A0%E9%99%A4%E6%9F%90%E4%B8%AA%E5%8E%86%E5%8F%B2%E8%AE%B0%E5%BD%95
-func (api *ChatAPI) DeleteHistory(appId, chatId string) error {
-	resp, err := api.client.DoRequest("DELETE", fmt.Sprintf("/api/core/chat/delHistory?chatId=%s&appId=%s", chatId, appId), nil)
+//
+// 使用示例：
+//
+//	// 只删除通过API产生的对话，避免误删其他渠道的历史记录
+//	err := chatAPI.DeleteHistory("your-app-id", "your-chat-id", "api")
+func (api *ChatAPI) DeleteHistory(appId, chatId string, source ...string) error {
+	return api.deleteHistory(context.Background(), appId, chatId, source...)
+}
+
+// deleteHistory 使用ctx删除单个历史记录
+func (api *ChatAPI) deleteHistory(ctx context.Context, appId, chatId string, source ...string) error {
+	path := fmt.Sprintf("/api/core/chat/delHistory?chatId=%s&appId=%s", chatId, appId) + sourceQuery(source)
+	resp, err := api.client.DoRequestContext(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
@@ -344,13 +918,21 @@ func (api *ChatAPI) DeleteHistory(appId, chatId string) error {
 //
 //	appId: 应用ID
 //
+//	source: 可选，对话来源，如api；不传或为空时清空所有来源的历史记录
+//
 // 返回值：
 //
 //	error: 如果请求失败，返回错误信息
 //
 // 接口文档：https://doc.fastgpt.cn/docs/introduction/development/openapi/chat#%E6%B8%85%E7%A9%BA%E6%89%80%E6%9C%89%E5%8E%86%E5%8F%B2%E8%AE%B0%E5%BD%95
-func (api *ChatAPI) ClearHistories(appId string) error {
-	resp, err := api.client.DoRequest("DELETE", fmt.Sprintf("/api/core/chat/clearHistories?appId=%s", appId), nil)
+//
+// 使用示例：
+//
+//	// 只清空通过API产生的历史记录
+//	err := chatAPI.ClearHistories("your-app-id", "api")
+func (api *ChatAPI) ClearHistories(appId string, source ...string) error {
+	path := fmt.Sprintf("/api/core/chat/clearHistories?appId=%s", appId) + sourceQuery(source)
+	resp, err := api.client.DoRequest("DELETE", path, nil)
 	if err != nil {
 		return err
 	}
@@ -363,6 +945,131 @@ func (api *ChatAPI) ClearHistories(appId string) error {
 	return nil
 }
 
+// sourceQuery 根据可选的对话来源生成查询参数，来源为空时返回空字符串
+func sourceQuery(source []string) string {
+	if len(source) == 0 || source[0] == "" {
+		return ""
+	}
+	return "&source=" + url.QueryEscape(source[0])
+}
+
+// 按时间批量删除历史记录的配置
+const (
+	historiesPageSize          = 30 // 分页读取历史记录时每页的数量
+	deleteHistoriesConcurrency = 4  // 并发删除的请求数
+)
+
+// DeleteHistoriesOlderThan 删除最后更新时间早于cutoff的历史记录
+//
+// 该方法先分页读取应用的全部历史记录，筛选出UpdateTime早于cutoff的对话，
+// 再以有限的并发逐个删除，适用于"只保留最近30天对话"等数据保留策略。
+//
+// 参数：
+//
+//	ctx: 上下文，用于读取和删除的每个请求，取消后正在进行的请求立即失败，且不再发起新的请求
+//	appId: 应用ID
+//	cutoff: 截止时间，早于该时间的对话会被删除
+//	source: 可选的对话来源，如"api"，只读取和删除该来源的对话
+//
+// 返回值：
+//
+//	int: 成功删除的对话数量
+//	error: 如果读取或删除失败，返回第一个错误；已删除的数量仍会返回
+//
+// 注意事项：
+//   - 置顶的对话同样会被删除
+//   - UpdateTime无法解析的对话会被跳过
+//
+// 使用示例：
+//
+//	deleted, err := chatAPI.DeleteHistoriesOlderThan(ctx, "your-app-id", time.Now().AddDate(0, 0, -30))
+func (api *ChatAPI) DeleteHistoriesOlderThan(ctx context.Context, appId string, cutoff time.Time, source ...string) (int, error) {
+	var chatSource model.ChatSource
+	if len(source) > 0 {
+		chatSource = model.ChatSource(source[0])
+	}
+
+	// 先读取全部历史记录，避免边删除边分页导致偏移量错位
+	var chatIds []string
+	for offset := 0; ; offset += historiesPageSize {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		historiesResp, err := api.getHistories(ctx, &model.GetHistoriesRequest{
+			AppId:    appId,
+			Offset:   offset,
+			PageSize: historiesPageSize,
+			Source:   chatSource,
+		})
+		if err != nil {
+			return 0, err // 读取历史记录失败，返回错误
+		}
+
+		for _, history := range historiesResp.List {
+			updateTime, err := time.Parse(time.RFC3339, history.UpdateTime)
+			if err != nil {
+				continue // 时间格式无法识别，跳过
+			}
+			if updateTime.Before(cutoff) {
+				chatIds = append(chatIds, history.ChatId)
+			}
+		}
+
+		if len(historiesResp.List) < historiesPageSize || offset+len(historiesResp.List) >= historiesResp.Total {
+			break
+		}
+	}
+
+	// 有限并发删除
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		deleted  int
+		firstErr error
+	)
+	jobs := make(chan string)
+	for i := 0; i < deleteHistoriesConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chatId := range jobs {
+				err := api.deleteHistory(ctx, appId, chatId, source...)
+
+				mu.Lock()
+				if err == nil {
+					deleted++
+				} else if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, chatId := range chatIds {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break // 出现错误后不再发起新的删除请求
+		}
+
+		select {
+		case jobs <- chatId:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return deleted, firstErr
+}
+
 // GetInit 获取单个对话初始化信息
 //
 // 该方法用于获取单个对话的初始化信息。
@@ -425,6 +1132,142 @@ func (api *ChatAPI) GetPaginationRecords(req *model.GetPaginationRecordsRequest)
 	return &result, nil
 }
 
+// ErrRecordNotFound 对话中不存在指定dataId的记录
+var ErrRecordNotFound = errors.New("对话记录不存在")
+
+// GetRecordDetail 获取单条AI回复的内容和完整响应数据
+//
+// 分页查找dataId对应的对话记录，再获取该记录的响应数据，合并为一个结构，
+// 省去分别调用GetPaginationRecords和GetResData再拼接的步骤。
+//
+// 参数：
+//
+//	appId: 应用ID
+//	chatId: 对话ID
+//	dataId: AI回复消息ID
+//
+// 返回值：
+//
+//	*model.ChatRecordDetail: 记录、文本内容、响应数据和去重后的引用
+//	error: 请求失败时返回错误，记录不存在时返回ErrRecordNotFound
+//
+// 使用示例：
+//
+//	detail, err := chatAPI.GetRecordDetail("your-app-id", "your-chat-id", "your-data-id")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(detail.Text)
+//	for _, q := range detail.Quotes {
+//	    fmt.Printf("引用: %s\n", q.SourceName)
+//	}
+func (api *ChatAPI) GetRecordDetail(appId, chatId, dataId string) (*model.ChatRecordDetail, error) {
+	record, err := api.findRecord(appId, chatId, dataId)
+	if err != nil {
+		return nil, err // 查找对话记录失败，返回错误
+	}
+
+	responseData, err := api.GetResData(appId, chatId, dataId)
+	if err != nil {
+		return nil, err // 获取响应数据失败，返回错误
+	}
+
+	return &model.ChatRecordDetail{
+		Record:       *record,
+		Text:         record.Text(),
+		ResponseData: responseData,
+		Quotes:       model.CollectQuotes(responseData),
+	}, nil
+}
+
+// findRecord 分页查找dataId对应的对话记录
+func (api *ChatAPI) findRecord(appId, chatId, dataId string) (*model.ChatRecord, error) {
+	count := 0
+	for offset := 0; ; offset += recordsPageSize {
+		recordsResp, err := api.GetPaginationRecords(&model.GetPaginationRecordsRequest{
+			AppId:    appId,
+			ChatId:   chatId,
+			Offset:   offset,
+			PageSize: recordsPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range recordsResp.List {
+			if recordsResp.List[i].DataId == dataId {
+				return &recordsResp.List[i], nil
+			}
+		}
+
+		count += len(recordsResp.List)
+		if len(recordsResp.List) < recordsPageSize || count >= recordsResp.Total {
+			return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, dataId)
+		}
+	}
+}
+
+// recordsPageSize 分页读取对话记录时每页的数量
+const recordsPageSize = 30
+
+// ResumeChat 获取对话初始化信息和全部历史记录
+//
+// 该方法用于"打开已有对话"的场景，一次性获取初始化信息（应用配置、变量等）和该对话的全部记录，
+// 记录按时间从早到晚排列，可直接用于渲染历史消息。
+//
+// 参数：
+//
+//	appId: 应用ID
+//	chatId: 对话ID
+//
+// 返回值：
+//
+//	*model.ChatInitResponse: 对话初始化信息
+//	[]model.ChatRecord: 按时间从早到晚排列的对话记录
+//	error: 如果请求失败，返回错误信息
+//
+// 注意事项：
+//   - FastGPT的分页从最新的记录开始计算偏移量，每页内部按时间正序排列，该方法会按此规则拼接各页
+//   - 继续对话时传入相同的ChatId即可由服务端加载上下文，无需把历史记录放入Messages
+//
+// 使用示例：
+//
+//	initResp, records, err := chatAPI.ResumeChat("your-app-id", "your-chat-id")
+func (api *ChatAPI) ResumeChat(appId, chatId string) (*model.ChatInitResponse, []model.ChatRecord, error) {
+	initResp, err := api.GetInit(appId, chatId)
+	if err != nil {
+		return nil, nil, err // 获取初始化信息失败，返回错误
+	}
+
+	var pages [][]model.ChatRecord
+	count := 0
+	for offset := 0; ; offset += recordsPageSize {
+		recordsResp, err := api.GetPaginationRecords(&model.GetPaginationRecordsRequest{
+			AppId:    appId,
+			ChatId:   chatId,
+			Offset:   offset,
+			PageSize: recordsPageSize,
+		})
+		if err != nil {
+			return initResp, nil, err // 获取对话记录失败，返回错误
+		}
+		pages = append(pages, recordsResp.List)
+		count += len(recordsResp.List)
+
+		if len(recordsResp.List) < recordsPageSize || count >= recordsResp.Total {
+			break
+		}
+	}
+
+	// 越靠后的页越早，倒序拼接得到从早到晚的完整记录
+	records := make([]model.ChatRecord, 0, count)
+	for i := len(pages) - 1; i >= 0; i-- {
+		records = append(records, pages[i]...)
+	}
+
+	return initResp, records, nil
+}
+
 // GetResData 获取单个对话记录运行详情
 //
 // 该方法用于获取单个对话记录的运行详情。
@@ -0,0 +1,106 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// pipeDoer 以管道作为响应体返回流式响应，测试可以逐段写入SSE数据
+type pipeDoer struct {
+	*clienttest.Fake
+	writers chan *io.PipeWriter // 每个请求的响应体写入端
+}
+
+// newPipeDoer 创建pipeDoer
+func newPipeDoer() *pipeDoer {
+	return &pipeDoer{Fake: clienttest.NewFake(), writers: make(chan *io.PipeWriter, 1)}
+}
+
+func (d *pipeDoer) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+	return d.DoRequestContext(context.Background(), method, path, body)
+}
+
+func (d *pipeDoer) DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	r, w := io.Pipe()
+	d.writers <- w
+	// 与真实的Transport一样，ctx取消后读取响应体返回ctx的错误
+	go func() {
+		<-ctx.Done()
+		w.CloseWithError(ctx.Err())
+	}()
+	return &http.Response{StatusCode: http.StatusOK, Body: r}, nil
+}
+
+func TestChatTextPartialResult(t *testing.T) {
+	doer := newPipeDoer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type chatTextResult struct {
+		text         string
+		usage        *model.Usage
+		finishReason string
+		err          error
+	}
+	done := make(chan chatTextResult, 1)
+	go func() {
+		text, usage, finishReason, err := NewChatAPI(doer).ChatText(ctx, &model.ChatRequest{})
+		done <- chatTextResult{text, usage, finishReason, err}
+	}()
+
+	var w *io.PipeWriter
+	select {
+	case w = <-doer.writers:
+	case r := <-done:
+		t.Fatalf("ChatText() returned before sending the request: %+v", r)
+	}
+	writes := []string{
+		"event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"你\"}}]}\n\n",
+		"event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"好\"}}]}\n\n",
+		"event: answer\n", // 管道写入完成表示前面的事件已处理
+	}
+	for _, s := range writes {
+		if _, err := io.WriteString(w, s); err != nil {
+			t.Fatalf("write error = %v", err)
+		}
+	}
+	cancel()
+
+	r := <-done
+	if !errors.Is(r.err, context.Canceled) {
+		t.Fatalf("ChatText() error = %v, want context.Canceled", r.err)
+	}
+	if r.text != "你好" {
+		t.Errorf("ChatText() text = %q, want %q", r.text, "你好")
+	}
+	if r.usage != nil || r.finishReason != "" {
+		t.Errorf("ChatText() usage = %v, finishReason = %q, want nil, empty", r.usage, r.finishReason)
+	}
+}
+
+func TestChatTextFinishReasonAndUsage(t *testing.T) {
+	stream := "event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"很长的回答\"}}]}\n\n" +
+		"event: answer\ndata: {\"choices\":[{\"delta\":{},\"finish_reason\":\"length\"}],\"usage\":{\"prompt_tokens\":10,\"completion_tokens\":20,\"total_tokens\":30}}\n\n" +
+		"event: answer\ndata: [DONE]\n\n"
+	fake := clienttest.NewFake().On("POST", "/api/v1/chat/completions", http.StatusOK, stream)
+
+	text, usage, finishReason, err := NewChatAPI(fake).ChatText(context.Background(), &model.ChatRequest{})
+	if err != nil {
+		t.Fatalf("ChatText() error = %v", err)
+	}
+	if text != "很长的回答" {
+		t.Errorf("text = %q", text)
+	}
+	if finishReason != model.FinishReasonLength {
+		t.Errorf("finishReason = %q, want %q", finishReason, model.FinishReasonLength)
+	}
+	if usage == nil || *usage != (model.Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}) {
+		t.Errorf("usage = %+v", usage)
+	}
+}
@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// Option 对话接口的可选配置
+type Option func(*ChatAPI)
+
+// WithChatIDGenerator 设置对话ID生成函数
+//
+// ChatWithResponseID、ChatText等高层方法在请求的ChatId为空时，会使用该函数生成对话ID，
+// 并通过返回的ChatResult.ChatId告知调用方。保存该ID后，重试或继续对话时传入同一ID，
+// 服务端就能关联到同一段对话上下文。未设置时默认生成UUID。
+//
+// 参数：
+//
+//	gen: 对话ID生成函数，为nil时使用默认的UUID生成函数
+//
+// 注意事项：
+//   - Chat、ChatContext等底层方法不会自动生成对话ID，请求原样发送
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx")
+//	fgpt.Chat = chat.NewChatAPI(fgpt.Client, chat.WithChatIDGenerator(func() string {
+//	    return "order-" + orderId
+//	}))
+func WithChatIDGenerator(gen func() string) Option {
+	return func(api *ChatAPI) {
+		if gen != nil {
+			api.chatIDGenerator = gen
+		}
+	}
+}
+
+// NewChatID 生成新的对话ID
+//
+// 使用WithChatIDGenerator设置的生成函数，未设置时返回随机UUID。
+func (api *ChatAPI) NewChatID() string {
+	if api.chatIDGenerator != nil {
+		return api.chatIDGenerator()
+	}
+	return newUUID()
+}
+
+// newUUID 生成随机的UUID（版本4）
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read不会返回错误
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
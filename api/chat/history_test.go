@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// ctxKey 测试中标记上下文的键
+type ctxKey struct{}
+
+// ctxRecorder 记录每个请求是否使用了带标记的上下文
+type ctxRecorder struct {
+	*clienttest.Fake
+
+	mu      sync.Mutex
+	missing []string // 没有使用调用方上下文的请求
+}
+
+func (r *ctxRecorder) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+	return r.DoRequestContext(context.Background(), method, path, body)
+}
+
+func (r *ctxRecorder) DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	if ctx.Value(ctxKey{}) == nil {
+		r.mu.Lock()
+		r.missing = append(r.missing, method+" "+path)
+		r.mu.Unlock()
+	}
+	return r.Fake.DoRequestContext(ctx, method, path, body)
+}
+
+func TestDeleteHistoriesOlderThanUsesContext(t *testing.T) {
+	now := time.Now()
+	fake := clienttest.NewFake().
+		OnData("POST", "/api/core/chat/getHistories", model.GetHistoriesResponse{
+			List: []model.ChatHistory{
+				{ChatId: "old1", UpdateTime: now.AddDate(0, 0, -40).Format(time.RFC3339)},
+				{ChatId: "old2", UpdateTime: now.AddDate(0, 0, -35).Format(time.RFC3339)},
+				{ChatId: "new", UpdateTime: now.Format(time.RFC3339)},
+			},
+			Total: 3,
+		}).
+		OnData("DELETE", "/api/core/chat/delHistory", nil)
+	recorder := &ctxRecorder{Fake: fake}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	deleted, err := NewChatAPI(recorder).DeleteHistoriesOlderThan(ctx, "app", now.AddDate(0, 0, -30), "api")
+	if err != nil {
+		t.Fatalf("DeleteHistoriesOlderThan() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteHistoriesOlderThan() = %d, want 2", deleted)
+	}
+	if len(recorder.missing) > 0 {
+		t.Errorf("requests without the caller's context: %s", strings.Join(recorder.missing, ", "))
+	}
+}
+
+func TestDeleteHistoriesOlderThanCanceled(t *testing.T) {
+	fake := clienttest.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deleted, err := NewChatAPI(fake).DeleteHistoriesOlderThan(ctx, "app", time.Now())
+	if err != context.Canceled || deleted != 0 {
+		t.Errorf("DeleteHistoriesOlderThan() = %d, %v, want 0, context.Canceled", deleted, err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("sent %d requests after cancel", len(fake.Calls()))
+	}
+}
@@ -0,0 +1,200 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// 对话接口根据Stream和Detail的组合返回四种不同的响应格式：
+//
+//	Stream=true,  Detail=false: SSE流，只有answer事件（ChatStreamSimple）
+//	Stream=true,  Detail=true:  SSE流，另有flowNodeStatus、flowResponses等事件（ChatStreamDetail）
+//	Stream=false, Detail=false: JSON对象，格式同model.ChatResponse（ChatSyncSimple）
+//	Stream=false, Detail=true:  JSON对象，额外包含responseData和newVariables，格式同model.ChatDetailResponse（ChatSyncDetail）
+//
+// 以下四个方法分别设置对应的标志并解析为对应的类型，避免手动组合时解析了错误的格式。
+// 它们都在请求的副本上设置标志，不会修改调用方传入的请求；需要处理原始事件时请使用Chat。
+
+// ChatStreamSimple 以流式、不带Detail的方式对话，返回完整回答
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream和Detail会被覆盖
+//
+// 返回值：
+//
+//	*model.ChatResult: 对话结果，与ChatWithResponseID相同；出错时包含中断前已接收的部分回答
+//	error: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 使用示例：
+//
+//	result, err := chatAPI.ChatStreamSimple(ctx, req)
+//	fmt.Println(result.Text)
+func (api *ChatAPI) ChatStreamSimple(ctx context.Context, req *model.ChatRequest) (*model.ChatResult, error) {
+	simpleReq := *req
+	simpleReq.Detail = false
+	return api.streamResult(ctx, &simpleReq, nil)
+}
+
+// ChatStreamDetail 以流式、带Detail的方式对话，返回完整回答和节点运行详情
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream和Detail会被覆盖
+//
+// 返回值：
+//
+//	*model.ChatStreamDetailResult: 对话结果、flowResponses事件中的节点运行详情和其他事件；出错时包含中断前已接收的部分内容
+//	error: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 使用示例：
+//
+//	result, err := chatAPI.ChatStreamDetail(ctx, req)
+//	if err == nil {
+//	    fmt.Println(result.Text)
+//	    for _, q := range result.Quotes() {
+//	        fmt.Println(q.SourceName)
+//	    }
+//	    for _, e := range result.Events {
+//	        fmt.Println(e.Type, e.Data) // 如toolCall、interactive或通过RegisterEventDecoder解码的自定义事件
+//	    }
+//	}
+func (api *ChatAPI) ChatStreamDetail(ctx context.Context, req *model.ChatRequest) (*model.ChatStreamDetailResult, error) {
+	detailReq := *req
+	detailReq.Detail = true
+
+	var flow []model.FlowResponse
+	var events []model.StreamEvent
+	result, err := api.streamResult(ctx, &detailReq, func(eventType string, data interface{}) error {
+		switch eventType {
+		case EventReconnect:
+			flow, events = nil, nil // 服务端会重新运行工作流
+		case EventFlowResponses:
+			if event, ok := data.(model.FlowResponsesEvent); ok {
+				flow = append(flow, event.Responses...)
+			}
+		case EventAnswer, EventFastAnswer, EventFlowNodeStatus:
+			// 回答已汇总到结果中，节点状态只用于展示进度
+		default:
+			events = append(events, model.StreamEvent{Type: eventType, Data: data})
+		}
+		return nil
+	})
+	if result == nil {
+		return nil, err
+	}
+	return &model.ChatStreamDetailResult{ChatResult: *result, FlowResponses: flow, Events: events}, err
+}
+
+// ChatSyncSimple 以非流式、不带Detail的方式对话
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream和Detail会被覆盖
+//
+// 返回值：
+//
+//	*model.ChatResponse: 对话响应，可通过Text获取回答文本
+//	error: 如果请求失败或响应解析失败，返回错误信息
+//
+// 使用示例：
+//
+//	resp, err := chatAPI.ChatSyncSimple(ctx, req)
+//	if err == nil {
+//	    fmt.Println(resp.Text())
+//	}
+func (api *ChatAPI) ChatSyncSimple(ctx context.Context, req *model.ChatRequest) (*model.ChatResponse, error) {
+	syncReq := *req
+	syncReq.Stream = false
+	syncReq.Detail = false
+
+	var chatResp model.ChatResponse
+	if err := api.chatSync(ctx, &syncReq, &chatResp); err != nil {
+		return nil, err
+	}
+	return &chatResp, nil
+}
+
+// ChatSyncDetail 以非流式、带Detail的方式对话
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	req: 对话请求，Stream和Detail会被覆盖
+//
+// 返回值：
+//
+//	*model.ChatDetailResponse: 对话响应，包含各节点的运行详情ResponseData
+//	error: 如果请求失败或响应解析失败，返回错误信息
+//
+// 使用示例：
+//
+//	resp, err := chatAPI.ChatSyncDetail(ctx, req)
+//	if err == nil {
+//	    fmt.Println(resp.Text())
+//	    quotes := resp.AllQuotes()
+//	    out, _ := resp.NodeOutput("查询天气")
+//	}
+func (api *ChatAPI) ChatSyncDetail(ctx context.Context, req *model.ChatRequest) (*model.ChatDetailResponse, error) {
+	syncReq := *req
+	syncReq.Stream = false
+	syncReq.Detail = true
+
+	var detailResp model.ChatDetailResponse
+	if err := api.chatSync(ctx, &syncReq, &detailResp); err != nil {
+		return nil, err
+	}
+	return &detailResp, nil
+}
+
+// chatSync 发送非流式对话请求并将响应解析到v
+//
+// 非流式对话直接返回OpenAI格式的JSON，没有包装在code/data中，因此不能使用ParseResponse解析成功的响应。
+func (api *ChatAPI) chatSync(ctx context.Context, req *model.ChatRequest, v interface{}) error {
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/v1/chat/completions", req)
+	if err != nil {
+		return err // 请求发送失败，返回错误
+	}
+
+	// 非2xx响应按普通响应解析出错误
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if err := api.client.ParseResponse(resp, nil); err != nil {
+			return err
+		}
+		return &client.APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.StatusCode,
+			Message:    resp.Status,
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取对话响应失败: %w", err)
+	}
+
+	// 部分部署在HTTP 200中返回code不为200的错误
+	var base model.BaseResponse
+	if err := json.Unmarshal(body, &base); err == nil && base.Code != 0 && base.Code != 200 {
+		return &client.APIError{
+			StatusCode: resp.StatusCode,
+			Code:       base.Code,
+			StatusText: base.StatusText,
+			Message:    base.Message,
+		}
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("解析对话响应失败: %w", err)
+	}
+	return nil
+}
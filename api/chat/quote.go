@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"github.com/xxjwxc/fastgpt/api/dataset"
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// ResolveQuotes 补全引用的集合名称和知识库名称
+//
+// 对话响应中的引用（QuoteItem）不一定带有可读的文档名称。该方法按集合ID查询集合详情，
+// 再按知识库ID查询知识库详情，补全后可直接用于渲染引用标注。
+// 同一次调用中相同的集合和知识库只查询一次，多条引用来自同一文档时不会重复请求。
+//
+// 参数：
+//
+//	quotes: 引用列表，通常来自ResponseDataItem.QuoteList或model.CollectQuotes
+//
+// 返回值：
+//
+//	[]model.ResolvedQuote: 与quotes顺序一致的补全结果
+//	error: 查询失败时返回错误；集合或知识库已被删除时不返回错误，保留引用中已有的信息
+//
+// 使用示例：
+//
+//	resolved, err := chatAPI.ResolveQuotes(item.QuoteList)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, q := range resolved {
+//	    fmt.Printf("据《%s》（%s）\n", q.Title(), q.DatasetName)
+//	}
+func (api *ChatAPI) ResolveQuotes(quotes []model.QuoteItem) ([]model.ResolvedQuote, error) {
+	datasetAPI := dataset.NewDatasetAPI(api.client)
+	collections := make(map[string]*model.CollectionInfo) // 已查询的集合，已删除的集合为nil
+	datasetNames := make(map[string]string)               // 已查询的知识库名称
+
+	resolved := make([]model.ResolvedQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		r := model.ResolvedQuote{QuoteItem: quote}
+		datasetId := quote.DatasetID
+
+		if quote.CollectionID != "" {
+			info, ok := collections[quote.CollectionID]
+			if !ok {
+				var err error
+				info, err = datasetAPI.GetCollectionDetail(quote.CollectionID)
+				if err != nil && !client.IsNotFound(err) {
+					return nil, err // 查询集合详情失败，返回错误
+				}
+				collections[quote.CollectionID] = info
+			}
+			if info != nil {
+				r.CollectionName = info.Name
+				r.RawLink = info.RawLink
+				if r.SourceName == "" {
+					r.SourceName = info.SourceName
+				}
+				if datasetId == "" {
+					datasetId = info.DatasetIDString()
+				}
+			}
+		}
+
+		if datasetId != "" {
+			name, ok := datasetNames[datasetId]
+			if !ok {
+				info, err := datasetAPI.GetDatasetDetail(&model.DatasetDetailRequest{Id: datasetId})
+				if err != nil && !client.IsNotFound(err) {
+					return nil, err // 查询知识库详情失败，返回错误
+				}
+				if info != nil {
+					name = info.Name
+				}
+				datasetNames[datasetId] = name
+			}
+			r.DatasetName = name
+		}
+
+		resolved = append(resolved, r)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,235 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// recordedEvent ParseSSE交给handler的事件
+type recordedEvent struct {
+	eventType string
+	data      interface{}
+}
+
+// parseAll 解析完整的SSE文本并返回全部事件
+func parseAll(t *testing.T, stream string) []recordedEvent {
+	t.Helper()
+
+	var events []recordedEvent
+	err := ParseSSE(strings.NewReader(stream), func(eventType string, data interface{}) error {
+		events = append(events, recordedEvent{eventType: eventType, data: data})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseSSE() error = %v", err)
+	}
+	return events
+}
+
+func TestParseSSECRLF(t *testing.T) {
+	stream := "event: answer\r\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"你\"}}]}\r\n" +
+		"\r\n" +
+		"event: answer\r\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"好\"}}]}\r\n" +
+		"\r\n" +
+		"event: answer\r\n" +
+		"data: [DONE]\r\n" +
+		"\r\n"
+
+	events := parseAll(t, stream)
+	if len(events) != 3 {
+		t.Fatalf("ParseSSE() got %d events, want 3", len(events))
+	}
+
+	var text strings.Builder
+	for _, e := range events[:2] {
+		if e.eventType != EventAnswer {
+			t.Errorf("event type = %q, want %q", e.eventType, EventAnswer)
+		}
+		answer, ok := e.data.(model.AnswerEvent)
+		if !ok {
+			t.Fatalf("event data = %T, want model.AnswerEvent", e.data)
+		}
+		text.WriteString(answer.Choices[0].Delta.Content)
+	}
+	if text.String() != "你好" {
+		t.Errorf("answer = %q, want %q", text.String(), "你好")
+	}
+	if events[2].data != "[DONE]" {
+		t.Errorf("last event data = %v, want [DONE]", events[2].data)
+	}
+}
+
+func TestParseSSECRLFFlushesOnBlankLine(t *testing.T) {
+	r, w := io.Pipe()
+	received := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- ParseSSE(r, func(eventType string, data interface{}) error {
+			received <- eventType
+			return nil
+		})
+	}()
+
+	// 只写入第一个事件且不关闭连接，事件应在读到空行时立即交给handler
+	if _, err := io.WriteString(w, "event: flowNodeStatus\r\ndata: {\"status\":\"running\",\"name\":\"AI 对话\"}\r\n\r\n"); err != nil {
+		t.Fatalf("write error = %v", err)
+	}
+	select {
+	case eventType := <-received:
+		if eventType != EventFlowNodeStatus {
+			t.Errorf("event type = %q, want %q", eventType, EventFlowNodeStatus)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event was not flushed on the CRLF blank line")
+	}
+
+	w.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("ParseSSE() error = %v", err)
+	}
+}
+
+func TestParseSSEFastAnswer(t *testing.T) {
+	stream := "event: fastAnswer\ndata: {\"choices\":[{\"delta\":{\"content\":\"缓存\"}}]}\n\n" +
+		"event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"回答\"}}]}\n\n"
+
+	events := parseAll(t, stream)
+	want := []string{EventFastAnswer, EventAnswer}
+	if len(events) != len(want) {
+		t.Fatalf("ParseSSE() got %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.eventType != want[i] {
+			t.Errorf("event %d type = %q, want %q", i, e.eventType, want[i])
+		}
+		if _, ok := e.data.(model.AnswerEvent); !ok {
+			t.Errorf("event %d data = %T, want model.AnswerEvent", i, e.data)
+		}
+	}
+}
+
+func TestChatWithResponseIDFastAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   bool
+	}{
+		{
+			name:   "包含fastAnswer",
+			stream: "event: fastAnswer\ndata: {\"choices\":[{\"delta\":{\"content\":\"指定回复\"}}]}\n\nevent: answer\ndata: [DONE]\n\n",
+			want:   true,
+		},
+		{
+			name:   "只有answer",
+			stream: "event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"模型回答\"}}]}\n\nevent: answer\ndata: [DONE]\n\n",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().On("POST", "/api/v1/chat/completions", http.StatusOK, tt.stream)
+			result, err := NewChatAPI(fake).ChatWithResponseID(context.Background(), &model.ChatRequest{})
+			if err != nil {
+				t.Fatalf("ChatWithResponseID() error = %v", err)
+			}
+			if result.FastAnswer != tt.want {
+				t.Errorf("FastAnswer = %v, want %v", result.FastAnswer, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSSERawEvents(t *testing.T) {
+	RegisterEventDecoder("nodeResponse", func(data []byte) (interface{}, error) {
+		var resp model.FlowResponse
+		err := json.Unmarshal(data, &resp)
+		return resp, err
+	})
+	defer RegisterEventDecoder("nodeResponse", nil)
+
+	tests := []struct {
+		name      string
+		stream    string
+		eventType string
+		want      interface{}
+	}{
+		{
+			name:      "未知事件为原始字符串",
+			stream:    "event: newEvent\ndata: {\"x\":1}\n\n",
+			eventType: "newEvent",
+			want:      `{"x":1}`,
+		},
+		{
+			name:      "已注册的自定义事件",
+			stream:    "event: nodeResponse\ndata: {\"nodeId\":\"n1\",\"moduleName\":\"AI 对话\"}\n\n",
+			eventType: "nodeResponse",
+			want:      model.FlowResponse{NodeId: "n1", ModuleName: "AI 对话"},
+		},
+		{
+			name:      "toolCall为原始字符串",
+			stream:    "event: toolCall\ndata: {\"id\":\"t1\"}\n\n",
+			eventType: EventToolCall,
+			want:      `{"id":"t1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := parseAll(t, tt.stream)
+			if len(events) != 1 {
+				t.Fatalf("ParseSSE() got %d events, want 1", len(events))
+			}
+			if events[0].eventType != tt.eventType {
+				t.Errorf("event type = %q, want %q", events[0].eventType, tt.eventType)
+			}
+			if !reflect.DeepEqual(events[0].data, tt.want) {
+				t.Errorf("event data = %#v, want %#v", events[0].data, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatStreamDetailEvents(t *testing.T) {
+	RegisterEventDecoder("nodeResponse", func(data []byte) (interface{}, error) {
+		var resp model.FlowResponse
+		err := json.Unmarshal(data, &resp)
+		return resp, err
+	})
+	defer RegisterEventDecoder("nodeResponse", nil)
+
+	stream := "event: flowNodeStatus\ndata: {\"status\":\"running\",\"name\":\"AI 对话\"}\n\n" +
+		"event: toolCall\ndata: {\"id\":\"t1\"}\n\n" +
+		"event: newEvent\ndata: {\"x\":1}\n\n" +
+		"event: nodeResponse\ndata: {\"nodeId\":\"n1\"}\n\n" +
+		"event: answer\ndata: {\"choices\":[{\"delta\":{\"content\":\"回答\"}}]}\n\n" +
+		"event: answer\ndata: [DONE]\n\n"
+	fake := clienttest.NewFake().On("POST", "/api/v1/chat/completions", http.StatusOK, stream)
+
+	result, err := NewChatAPI(fake).ChatStreamDetail(context.Background(), &model.ChatRequest{})
+	if err != nil {
+		t.Fatalf("ChatStreamDetail() error = %v", err)
+	}
+	if result.Text != "回答" {
+		t.Errorf("Text = %q, want %q", result.Text, "回答")
+	}
+	want := []model.StreamEvent{
+		{Type: EventToolCall, Data: `{"id":"t1"}`},
+		{Type: "newEvent", Data: `{"x":1}`},
+		{Type: "nodeResponse", Data: model.FlowResponse{NodeId: "n1"}},
+	}
+	if !reflect.DeepEqual(result.Events, want) {
+		t.Errorf("Events = %#v, want %#v", result.Events, want)
+	}
+}
@@ -0,0 +1,132 @@
+package chat
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// NodeTiming 单个工作流节点的运行时间
+type NodeTiming struct {
+	NodeId string    // 节点ID，服务端未返回时为空
+	Name   string    // 节点名称
+	Status string    // 最终状态：running, completed, failed
+	Start  time.Time // 开始运行的时间
+	End    time.Time // 结束运行的时间，仍在运行时为零值
+}
+
+// Duration 返回节点的运行时长，仍在运行时返回0
+func (t NodeTiming) Duration() time.Duration {
+	if t.End.IsZero() {
+		return 0
+	}
+	return t.End.Sub(t.Start)
+}
+
+// NodeTracker 根据flowNodeStatus事件记录各节点的运行时间
+//
+// 同一节点的running与completed/failed事件按NodeId匹配，服务端未返回NodeId时按节点名称匹配。
+// 部分服务端版本只发送running事件，此时一个节点会在下一个节点开始运行时视为结束，
+// 最后一个节点在调用Finish时结束。NodeTracker可以在多个goroutine中并发使用。
+//
+// 使用示例：
+//
+//	tracker := chat.NewNodeTracker()
+//	err := chatAPI.Chat(req, func(eventType string, data interface{}) error {
+//	    tracker.Observe(eventType, data)
+//	    return nil
+//	})
+//	tracker.Finish()
+//	for _, t := range tracker.Timings() {
+//	    fmt.Printf("%s: %v\n", t.Name, t.Duration())
+//	}
+type NodeTracker struct {
+	mu      sync.Mutex
+	timings []NodeTiming     // 按开始时间排列的节点运行时间
+	running map[string]int   // 运行中节点的键到timings下标的映射
+	now     func() time.Time // 当前时间，便于替换
+}
+
+// NewNodeTracker 创建节点运行时间记录器
+func NewNodeTracker() *NodeTracker {
+	return &NodeTracker{
+		running: make(map[string]int),
+		now:     time.Now,
+	}
+}
+
+// Observe 处理一个SSE事件，非flowNodeStatus事件会被忽略
+//
+// 参数：
+//
+//	eventType: 事件类型
+//	data: 事件数据
+func (t *NodeTracker) Observe(eventType string, data interface{}) {
+	event, ok := data.(model.FlowNodeStatusEvent)
+	if eventType != EventFlowNodeStatus || !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	key := event.NodeId
+	if key == "" {
+		key = event.Name
+	}
+
+	switch event.Status {
+	case model.NodeStatusCompleted, model.NodeStatusFailed:
+		if i, ok := t.running[key]; ok {
+			t.timings[i].Status = event.Status
+			t.timings[i].End = now
+			delete(t.running, key)
+		}
+	default:
+		// 服务端只发送running事件时，新节点开始运行意味着之前的节点已结束
+		if !t.hasExplicitEnd() {
+			t.finishRunning(now, model.NodeStatusCompleted)
+		}
+		t.running[key] = len(t.timings)
+		t.timings = append(t.timings, NodeTiming{
+			NodeId: event.NodeId,
+			Name:   event.Name,
+			Status: model.NodeStatusRunning,
+			Start:  now,
+		})
+	}
+}
+
+// Finish 将仍在运行的节点标记为完成，通常在对话结束后调用
+func (t *NodeTracker) Finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finishRunning(t.now(), model.NodeStatusCompleted)
+}
+
+// Timings 返回按开始时间排列的节点运行时间
+func (t *NodeTracker) Timings() []NodeTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := make([]NodeTiming, len(t.timings))
+	copy(timings, t.timings)
+	return timings
+}
+
+// hasExplicitEnd 判断是否已收到过completed或failed事件，调用者需持有锁
+func (t *NodeTracker) hasExplicitEnd() bool {
+	return len(t.timings) > len(t.running)
+}
+
+// finishRunning 结束所有运行中的节点，调用者需持有锁
+func (t *NodeTracker) finishRunning(at time.Time, status string) {
+	for key, i := range t.running {
+		t.timings[i].Status = status
+		t.timings[i].End = at
+		delete(t.running, key)
+	}
+}
@@ -0,0 +1,174 @@
+package dataset
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// defaultListCacheSize 集合列表缓存的最大条目数
+const defaultListCacheSize = 256
+
+// Option 知识库接口的可选配置
+type Option func(*DatasetAPI)
+
+// WithListCache 开启集合列表的短时缓存
+//
+// 开启后，GetCollectionList按(datasetId, parentId, offset, pageSize, searchText)缓存响应，
+// 在ttl内相同的请求直接返回缓存，不再发送HTTP请求，适合反复刷新同一知识库的看板页面。
+// 通过当前实例创建集合会清空该知识库的缓存，更新或删除集合会清空全部缓存。
+// 缓存最多保存256条，超出时优先淘汰最早过期的条目。
+//
+// 参数：
+//
+//	ttl: 缓存有效期，小于等于0时不开启缓存
+//
+// 注意事项：
+//   - 其他进程、FastGPT页面上的修改以及数据训练进度（dataAmount、trainingAmount）
+//     在缓存过期前不可见，ttl应设置为界面可以接受的延迟，通常为几秒
+//   - 缓存只作用于当前DatasetAPI实例
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx")
+//	fgpt.Dataset = dataset.NewDatasetAPI(fgpt.Client, dataset.WithListCache(5*time.Second))
+func WithListCache(ttl time.Duration) Option {
+	return func(api *DatasetAPI) {
+		if ttl > 0 {
+			api.listCache = newListCache(ttl, defaultListCacheSize)
+		}
+	}
+}
+
+// listCacheKey 集合列表缓存键
+type listCacheKey struct {
+	datasetId  string
+	parentId   string
+	offset     int
+	pageSize   int
+	searchText string
+}
+
+// listCacheEntry 集合列表缓存条目
+type listCacheEntry struct {
+	resp    model.CollectionListResponse
+	expires time.Time
+}
+
+// listCache 带过期时间和容量上限的集合列表缓存，可以在多个goroutine中并发使用
+type listCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[listCacheKey]listCacheEntry
+}
+
+// newListCache 创建集合列表缓存
+func newListCache(ttl time.Duration, size int) *listCache {
+	return &listCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[listCacheKey]listCacheEntry),
+	}
+}
+
+// cacheKey 根据请求生成缓存键，排序字段在客户端处理，不参与缓存键
+func cacheKey(req *model.CollectionListRequest) listCacheKey {
+	key := listCacheKey{
+		datasetId:  req.DatasetId,
+		offset:     req.Offset,
+		pageSize:   req.PageSize,
+		searchText: req.SearchText,
+	}
+	if req.ParentId != nil {
+		key.parentId = *req.ParentId
+	}
+	return key
+}
+
+// get 返回未过期的缓存副本，缓存未开启时始终未命中
+func (c *listCache) get(req *model.CollectionListRequest) (*model.CollectionListResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey(req)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return copyListResponse(&entry.resp), true
+}
+
+// put 保存响应副本，避免调用方修改返回值影响缓存
+func (c *listCache) put(req *model.CollectionListRequest, resp *model.CollectionListResponse) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[cacheKey(req)] = listCacheEntry{
+		resp:    *copyListResponse(resp),
+		expires: now.Add(c.ttl),
+	}
+}
+
+// evict 删除已过期的条目，仍然已满时删除最早过期的条目，调用者需持有锁
+func (c *listCache) evict(now time.Time) {
+	var oldestKey listCacheKey
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest.IsZero() || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// invalidate 删除指定知识库的缓存，datasetId为空时删除全部缓存
+func (c *listCache) invalidate(datasetId string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if datasetId == "" || key.datasetId == datasetId {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// invalidateListCache 集合发生变化后清除相关的列表缓存
+func (api *DatasetAPI) invalidateListCache(datasetId string) {
+	api.listCache.invalidate(datasetId)
+}
+
+// copyListResponse 复制集合列表响应，列表元素为值类型，复制切片即可隔离修改
+func copyListResponse(resp *model.CollectionListResponse) *model.CollectionListResponse {
+	list := make([]model.CollectionInfo, len(resp.List))
+	copy(list, resp.List)
+	return &model.CollectionListResponse{List: list, Total: resp.Total}
+}
@@ -0,0 +1,75 @@
+package dataset
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/xxjwxc/fastgpt/client"
+)
+
+// WithContext 返回绑定了ctx的知识库接口副本
+//
+// 副本发出的所有请求都使用ctx，适合为SyncDirectory、DeleteDatasetRecursive、PushDataInBatches、
+// DeleteDataByTag等由多个请求组成的方法设置一个总的截止时间：ctx到期或被取消后，
+// 正在进行和后续的请求立即失败，方法停止执行并返回已完成部分的结果（如已删除的数量、已推送的汇总结果）和ctx的错误。
+// 每个步骤直接使用剩余的总时间，而不是预先平均分配，避免较慢的步骤在总时间尚未用完时失败。
+//
+// 参数：
+//
+//	ctx: 请求使用的上下文
+//
+// 返回值：
+//
+//	*DatasetAPI: 共享配置（缓存、默认检索参数等）的副本，原实例不受影响
+//
+// 注意事项：
+//   - 通过client.WithCallContext、WithCallTimeout等为单次请求指定了可取消的上下文时，以单次请求的上下文为准
+//
+// 使用示例：
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+//	defer cancel()
+//	deleted, err := datasetAPI.WithContext(ctx).DeleteDatasetRecursive(folderId)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    log.Printf("超时，已删除%d个知识库", deleted)
+//	}
+func (api *DatasetAPI) WithContext(ctx context.Context) *DatasetAPI {
+	bound := *api
+	bound.client = &contextDoer{Doer: api.client, ctx: ctx}
+	return &bound
+}
+
+// contextDoer 为不带上下文的请求使用绑定的上下文
+type contextDoer struct {
+	client.Doer
+	ctx context.Context
+}
+
+// DoRequest 使用绑定的上下文发送请求
+func (d *contextDoer) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+	return d.Doer.DoRequestContext(d.ctx, method, path, body)
+}
+
+// DoRequestContext 传入的上下文不可取消（如context.Background()）时使用绑定上下文的截止时间和取消信号
+//
+// 传入上下文中的值（如client.WithCallHeader设置的请求头）仍然保留。
+func (d *contextDoer) DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	if ctx.Done() == nil {
+		ctx = &boundContext{Context: d.ctx, values: ctx}
+	}
+	return d.Doer.DoRequestContext(ctx, method, path, body)
+}
+
+// boundContext 截止时间和取消信号来自绑定的上下文，值优先从单次请求的上下文中查找
+type boundContext struct {
+	context.Context                 // 绑定的上下文，提供Deadline、Done和Err
+	values          context.Context // 单次请求的上下文
+}
+
+// Value 先查找单次请求上下文中的值，找不到时查找绑定的上下文
+func (c *boundContext) Value(key interface{}) interface{} {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
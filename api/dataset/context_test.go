@@ -0,0 +1,50 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestWithContextKeepsCallHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		cancel  bool
+		wantErr error
+	}{
+		{name: "保留单次请求头"},
+		{name: "绑定的上下文已取消", cancel: true, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().OnData("POST", "/api/core/dataset/data/pushData", map[string]int{"insertLen": 1})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			req := &model.DataPushRequest{CollectionId: "c1", TrainingType: "chunk", Data: []model.DatasetData{{Q: "问题"}}}
+			_, err := NewDatasetAPI(fake).WithContext(ctx).PushData(req, client.WithCallHeader("X-Trace-Id", "t1"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PushData() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			calls := fake.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("got %d calls, want 1", len(calls))
+			}
+			if got := calls[0].Header.Get("X-Trace-Id"); got != "t1" {
+				t.Errorf("X-Trace-Id = %q, want %q", got, "t1")
+			}
+		})
+	}
+}
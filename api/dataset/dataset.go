@@ -10,7 +10,10 @@
 package dataset
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
 
 	"github.com/xxjwxc/fastgpt/client"
 	"github.com/xxjwxc/fastgpt/model"
@@ -21,14 +24,18 @@ import (
 // 该结构体通过组合HTTP客户端，提供了与FastGPT知识库管理相关的所有功能，
 // 包括知识库管理、集合管理和数据管理。
 type DatasetAPI struct {
-	client *client.Client // HTTP客户端，用于发送API请求
+	client         client.Doer                   // HTTP客户端，用于发送API请求
+	listCache      *listCache                    // 集合列表缓存，未开启时为nil
+	autoTimestamps bool                          // 是否自动填充为空的时间字段
+	searchConfigs  map[string]model.SearchConfig // 各知识库的默认检索参数
 }
 
 // NewDatasetAPI 创建知识库接口实例
 //
 // 参数：
 //
-//	c: HTTP客户端实例，由外部传入
+//	c: HTTP客户端实例，由外部传入，测试时可传入clienttest.Fake
+//	opts: 可选配置项，如WithListCache、WithAutoTimestamps、WithSearchConfig
 //
 // 返回值：
 //
@@ -38,8 +45,12 @@ type DatasetAPI struct {
 //
 //	c := client.NewClient("https://cloud.fastgpt.cn", "sk-xxx")
 //	datasetAPI := dataset.NewDatasetAPI(c)
-func NewDatasetAPI(c *client.Client) *DatasetAPI {
-	return &DatasetAPI{client: c}
+func NewDatasetAPI(c client.Doer, opts ...Option) *DatasetAPI {
+	api := &DatasetAPI{client: c}
+	for _, opt := range opts {
+		opt(api)
+	}
+	return api
 }
 
 // CreateDataset 创建知识库
@@ -49,6 +60,7 @@ func NewDatasetAPI(c *client.Client) *DatasetAPI {
 // 参数：
 //
 //	req: 知识库创建请求，包含知识库名称、描述、标签和类型
+//	opts: 可选配置项，如WithCreateIfNotExists
 //
 // 返回值：
 //
@@ -65,25 +77,37 @@ func NewDatasetAPI(c *client.Client) *DatasetAPI {
 //	    Intro:        "这是一个测试知识库",
 //	}
 //	datasetId, err := datasetAPI.CreateDataset(req)
-func (api *DatasetAPI) CreateDataset(req *model.DatasetCreateRequest) (string, error) {
+//
+//	// 任务重试时避免重复创建
+//	datasetId, err := datasetAPI.CreateDataset(req, dataset.WithCreateIfNotExists())
+func (api *DatasetAPI) CreateDataset(req *model.DatasetCreateRequest, opts ...CreateOption) (string, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
+	if applyCreateOptions(opts).ifNotExists {
+		id, err := api.findDatasetByName(req.ParentId, req.Name, req.Type)
+		if err != nil {
+			return "", err // 查询失败，返回错误
+		}
+		if id != "" {
+			return id, nil // 返回已存在的知识库ID
+		}
+	}
+
 	resp, err := api.client.DoRequest("POST", "/api/core/dataset/create", req)
 	if err != nil {
 		return "", err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var data json.RawMessage
+	if err := api.client.ParseResponse(resp, &data); err != nil {
 		return "", err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为字符串类型的知识库ID
-	dataBytes, err := json.Marshal(baseResp.Data)
+	// 兼容不同服务端版本返回的字符串ID和对象两种形式
+	datasetId, err := parseID(data)
 	if err != nil {
-		return "", err // 转换失败，返回错误
-	}
-
-	var datasetId string
-	if err := json.Unmarshal(dataBytes, &datasetId); err != nil {
 		return "", err // 解析失败，返回错误
 	}
 
@@ -117,20 +141,9 @@ func (api *DatasetAPI) GetDatasetList(req *model.DatasetListRequest) ([]model.Da
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
-
-	// 将baseResp.Data转换为[]model.DatasetInfo类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
 	var datasetList []model.DatasetInfo
-	if err := json.Unmarshal(dataBytes, &datasetList); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &datasetList); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return datasetList, nil // 返回知识库列表
@@ -163,20 +176,9 @@ func (api *DatasetAPI) GetDatasetDetail(req *model.DatasetDetailRequest) (*model
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
-
-	// 将baseResp.Data转换为DatasetInfo类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
 	var datasetInfo model.DatasetInfo
-	if err := json.Unmarshal(dataBytes, &datasetInfo); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &datasetInfo); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return &datasetInfo, nil // 返回知识库详情
@@ -208,8 +210,7 @@ func (api *DatasetAPI) DeleteDataset(req *model.DatasetDeleteRequest) error {
 		return err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	if err := api.client.ParseResponse(resp, nil); err != nil {
 		return err // 响应解析失败，返回错误
 	}
 
@@ -236,32 +237,37 @@ func (api *DatasetAPI) DeleteDataset(req *model.DatasetDeleteRequest) error {
 //	req := &model.CollectionCreateRequest{
 //	    DatasetId:    "your-dataset-id",
 //	    Name:         "测试集合",
-//	    Type:         "virtual",
+//	    Type:         model.CollectionTypeVirtual,
 //	    TrainingType: "chunk",
 //	}
 //	collectionId, err := datasetAPI.CreateCollection(req)
 func (api *DatasetAPI) CreateCollection(req *model.CollectionCreateRequest) (string, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
+	if !req.Type.IsValid() {
+		return "", fmt.Errorf("未知的集合类型: %q", req.Type)
+	}
+
 	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/create", req)
 	if err != nil {
 		return "", err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var data json.RawMessage
+	if err := api.client.ParseResponse(resp, &data); err != nil {
 		return "", err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为字符串类型的集合ID
-	dataBytes, err := json.Marshal(baseResp.Data)
+	// 兼容不同服务端版本返回的字符串ID和对象两种形式
+	collectionId, err := parseID(data)
 	if err != nil {
-		return "", err // 转换失败，返回错误
-	}
-
-	var collectionId string
-	if err := json.Unmarshal(dataBytes, &collectionId); err != nil {
 		return "", err // 解析失败，返回错误
 	}
 
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
+
 	return collectionId, nil // 返回集合ID
 }
 
@@ -272,6 +278,7 @@ func (api *DatasetAPI) CreateCollection(req *model.CollectionCreateRequest) (str
 // 参数：
 //
 //	req: 纯文本集合创建请求，包含文本内容、知识库ID等
+//	opts: 可选配置项，如WithCreateIfNotExists
 //
 // 返回值：
 //
@@ -289,27 +296,33 @@ func (api *DatasetAPI) CreateCollection(req *model.CollectionCreateRequest) (str
 //	    TrainingType: "chunk",
 //	}
 //	createResp, err := datasetAPI.CreateTextCollection(req)
-func (api *DatasetAPI) CreateTextCollection(req *model.CollectionCreateTextRequest) (*model.CollectionCreateResponse, error) {
+func (api *DatasetAPI) CreateTextCollection(req *model.CollectionCreateTextRequest, opts ...CreateOption) (*model.CollectionCreateResponse, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
+	if applyCreateOptions(opts).ifNotExists {
+		existing, err := api.findCollectionsByName(req.DatasetId, req.ParentId, req.Name)
+		if err != nil {
+			return nil, err // 查询失败，返回错误
+		}
+		if len(existing) > 0 {
+			// 已存在时不会重新训练，Results为空
+			return &model.CollectionCreateResponse{CollectionId: existing[0]}, nil
+		}
+	}
+
 	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/create/text", req)
 	if err != nil {
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var createResp model.CollectionCreateResponse
+	if err := api.client.ParseResponse(resp, &createResp); err != nil {
 		return nil, err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为CollectionCreateResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
-	var createResp model.CollectionCreateResponse
-	if err := json.Unmarshal(dataBytes, &createResp); err != nil {
-		return nil, err // 解析失败，返回错误
-	}
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
 
 	return &createResp, nil // 返回集合创建响应
 }
@@ -338,26 +351,21 @@ func (api *DatasetAPI) CreateTextCollection(req *model.CollectionCreateTextReque
 //	}
 //	createResp, err := datasetAPI.CreateLinkCollection(req)
 func (api *DatasetAPI) CreateLinkCollection(req *model.CollectionCreateLinkRequest) (*model.CollectionCreateResponse, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
 	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/create/link", req)
 	if err != nil {
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var createResp model.CollectionCreateResponse
+	if err := api.client.ParseResponse(resp, &createResp); err != nil {
 		return nil, err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为CollectionCreateResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
-	var createResp model.CollectionCreateResponse
-	if err := json.Unmarshal(dataBytes, &createResp); err != nil {
-		return nil, err // 解析失败，返回错误
-	}
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
 
 	return &createResp, nil // 返回集合创建响应
 }
@@ -387,26 +395,21 @@ func (api *DatasetAPI) CreateLinkCollection(req *model.CollectionCreateLinkReque
 //	}
 //	createResp, err := datasetAPI.CreateAPICollection(req)
 func (api *DatasetAPI) CreateAPICollection(req *model.CollectionCreateAPRequest) (*model.CollectionCreateResponse, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
 	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/create/apiCollection", req)
 	if err != nil {
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var createResp model.CollectionCreateResponse
+	if err := api.client.ParseResponse(resp, &createResp); err != nil {
 		return nil, err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为CollectionCreateResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
-	var createResp model.CollectionCreateResponse
-	if err := json.Unmarshal(dataBytes, &createResp); err != nil {
-		return nil, err // 解析失败，返回错误
-	}
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
 
 	return &createResp, nil // 返回集合创建响应
 }
@@ -414,6 +417,7 @@ func (api *DatasetAPI) CreateAPICollection(req *model.CollectionCreateAPRequest)
 // CreateExternalFileCollection 创建一个外部文件库集合（商业版）
 //
 // 该方法用于通过外部文件URL创建集合，系统会自动下载并处理外部文件。
+// 社区版部署会返回包装了client.ErrProFeatureRequired的错误。
 //
 // 参数：
 //
@@ -437,26 +441,21 @@ func (api *DatasetAPI) CreateAPICollection(req *model.CollectionCreateAPRequest)
 //	}
 //	createResp, err := datasetAPI.CreateExternalFileCollection(req)
 func (api *DatasetAPI) CreateExternalFileCollection(req *model.CollectionCreateExternalFileRequest) (*model.CollectionCreateResponse, error) {
-	resp, err := api.client.DoRequest("POST", "/api/proApi/core/dataset/collection/create/externalFileUrl", req)
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
+	resp, err := api.client.DoRequest("POST", "/api/proApi/core/dataset/collection/create/externalFileUrl", api.withExternalFileTimestamps(req))
 	if err != nil {
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var createResp model.CollectionCreateResponse
+	if err := api.client.ParseResponse(resp, &createResp); err != nil {
 		return nil, err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为CollectionCreateResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
-	var createResp model.CollectionCreateResponse
-	if err := json.Unmarshal(dataBytes, &createResp); err != nil {
-		return nil, err // 解析失败，返回错误
-	}
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
 
 	return &createResp, nil // 返回集合创建响应
 }
@@ -482,33 +481,61 @@ func (api *DatasetAPI) CreateExternalFileCollection(req *model.CollectionCreateE
 //	    DatasetId:  "your-dataset-id",
 //	    Offset:     0,
 //	    PageSize:   10,
+//	    SortField:  model.CollectionSortByUpdateTime, // 可选，在当前页内按更新时间排序
 //	}
 //	collectionList, err := datasetAPI.GetCollectionList(req)
 func (api *DatasetAPI) GetCollectionList(req *model.CollectionListRequest) (*model.CollectionListResponse, error) {
-	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/listV2", req)
-	if err != nil {
-		return nil, err // 请求发送失败，返回错误
-	}
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
+	// 命中缓存时不发送请求
+	if listResp, ok := api.listCache.get(req); ok {
+		sortCollections(listResp.List, req.SortField, req.SortOrder)
+		return listResp, nil
 	}
 
-	// 将baseResp.Data转换为CollectionListResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
+	resp, err := api.client.DoRequest("POST", "/api/core/dataset/collection/listV2", req)
 	if err != nil {
-		return nil, err // 转换失败，返回错误
+		return nil, err // 请求发送失败，返回错误
 	}
 
 	var listResp model.CollectionListResponse
-	if err := json.Unmarshal(dataBytes, &listResp); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &listResp); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
+	api.listCache.put(req, &listResp)
+
+	// 接口不支持服务端排序，在客户端对当前页排序
+	sortCollections(listResp.List, req.SortField, req.SortOrder)
 
 	return &listResp, nil // 返回集合列表
 }
 
+// sortCollections 按指定字段对集合列表排序，字段为空时保持原顺序
+//
+// 时间字段为ISO格式字符串，可以直接按字典序比较。
+func sortCollections(list []model.CollectionInfo, field model.CollectionSortField, order model.SortOrder) {
+	var less func(a, b model.CollectionInfo) bool
+	switch field {
+	case model.CollectionSortByCreateTime:
+		less = func(a, b model.CollectionInfo) bool { return a.CreateTime < b.CreateTime }
+	case model.CollectionSortByUpdateTime:
+		less = func(a, b model.CollectionInfo) bool { return a.UpdateTime < b.UpdateTime }
+	case model.CollectionSortByDataAmount:
+		less = func(a, b model.CollectionInfo) bool { return a.DataAmount < b.DataAmount }
+	default:
+		return
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if order == model.SortAsc {
+			return less(list[i], list[j])
+		}
+		return less(list[j], list[i])
+	})
+}
+
 // GetCollectionDetail 获取集合详情
 //
 // 该方法用于获取指定集合的详细信息。
@@ -533,20 +560,9 @@ func (api *DatasetAPI) GetCollectionDetail(collectionId string) (*model.Collecti
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
-
-	// 将baseResp.Data转换为CollectionInfo类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
 	var collectionInfo model.CollectionInfo
-	if err := json.Unmarshal(dataBytes, &collectionInfo); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &collectionInfo); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return &collectionInfo, nil // 返回集合详情
@@ -580,11 +596,12 @@ func (api *DatasetAPI) UpdateCollection(req *model.CollectionUpdateRequest) erro
 		return err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	if err := api.client.ParseResponse(resp, nil); err != nil {
 		return err // 响应解析失败，返回错误
 	}
 
+	api.invalidateListCache("") // 请求中不一定有知识库ID，清空全部缓存
+
 	return nil // 更新成功
 }
 
@@ -614,21 +631,24 @@ func (api *DatasetAPI) DeleteCollection(req *model.CollectionDeleteRequest) erro
 		return err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	if err := api.client.ParseResponse(resp, nil); err != nil {
 		return err // 响应解析失败，返回错误
 	}
 
+	api.invalidateListCache("") // 请求中只有集合ID，清空全部缓存
+
 	return nil // 删除成功
 }
 
 // PushData 为集合批量添加数据
 //
 // 该方法用于为指定集合批量添加数据，每次最多支持200条。
+// 数据推送后进入训练队列，接口只返回写入数量而不返回新数据的ID，需要ID时请使用ListRecentData。
 //
 // 参数：
 //
 //	req: 批量添加数据请求，包含集合ID、训练类型和数据列表
+//	opts: 单次请求配置，如client.WithCallTimeout、client.WithCallHeader
 //
 // 返回值：
 //
@@ -650,7 +670,7 @@ func (api *DatasetAPI) DeleteCollection(req *model.CollectionDeleteRequest) erro
 //	            CollectionId: "your-collection-id",
 //	            Q:            "问题1",
 //	            A:            "答案1",
-//	            UpdateTime:   "2024-01-01T00:00:00.000Z",
+//	            UpdateTime:   model.FormatTime(time.Now()), // 可选，或使用WithAutoTimestamps自动填充
 //	            Indexes: []model.Index{
 //	                {
 //	                    Text: "默认索引",
@@ -660,26 +680,25 @@ func (api *DatasetAPI) DeleteCollection(req *model.CollectionDeleteRequest) erro
 //	    },
 //	}
 //	pushResp, err := datasetAPI.PushData(req)
-func (api *DatasetAPI) PushData(req *model.DataPushRequest) (*model.DataPushResponse, error) {
-	resp, err := api.client.DoRequest("POST", "/api/core/dataset/data/pushData", req)
-	if err != nil {
-		return nil, err // 请求发送失败，返回错误
+func (api *DatasetAPI) PushData(req *model.DataPushRequest, opts ...client.CallOption) (*model.DataPushResponse, error) {
+	// 在本地校验索引类型，避免未知类型发送到服务端
+	for i, data := range req.Data {
+		if err := model.ValidateIndexes(data.Indexes); err != nil {
+			return nil, fmt.Errorf("第%d条数据: %w", i+1, err)
+		}
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
+	ctx, cancel := client.ApplyCallOptions(context.Background(), opts...)
+	defer cancel()
 
-	// 将baseResp.Data转换为DataPushResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/core/dataset/data/pushData", api.withPushTimestamps(req))
 	if err != nil {
-		return nil, err // 转换失败，返回错误
+		return nil, err // 请求发送失败，返回错误
 	}
 
 	var pushResp model.DataPushResponse
-	if err := json.Unmarshal(dataBytes, &pushResp); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &pushResp); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return &pushResp, nil // 返回批量添加数据响应
@@ -714,20 +733,9 @@ func (api *DatasetAPI) GetDataList(req *model.DataListRequest) (*model.DataListR
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
-
-	// 将baseResp.Data转换为DataListResponse类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
 	var dataList model.DataListResponse
-	if err := json.Unmarshal(dataBytes, &dataList); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &dataList); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return &dataList, nil // 返回数据列表
@@ -760,20 +768,9 @@ func (api *DatasetAPI) GetDataDetail(req *model.DataDetailRequest) (*model.Datas
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
-		return nil, err // 响应解析失败，返回错误
-	}
-
-	// 将baseResp.Data转换为DatasetData类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
-	}
-
 	var dataDetail model.DatasetData
-	if err := json.Unmarshal(dataBytes, &dataDetail); err != nil {
-		return nil, err // 解析失败，返回错误
+	if err := api.client.ParseResponse(resp, &dataDetail); err != nil {
+		return nil, err // 响应解析失败，返回错误
 	}
 
 	return &dataDetail, nil // 返回数据详情
@@ -802,13 +799,16 @@ func (api *DatasetAPI) GetDataDetail(req *model.DataDetailRequest) (*model.Datas
 //	}
 //	err := datasetAPI.UpdateData(req)
 func (api *DatasetAPI) UpdateData(req *model.DataUpdateRequest) error {
+	if err := model.ValidateIndexes(req.Indexes); err != nil {
+		return err // 索引类型无效，返回错误
+	}
+
 	resp, err := api.client.DoRequest("PUT", "/api/core/dataset/data/update", req)
 	if err != nil {
 		return err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	if err := api.client.ParseResponse(resp, nil); err != nil {
 		return err // 响应解析失败，返回错误
 	}
 
@@ -841,8 +841,7 @@ func (api *DatasetAPI) DeleteData(req *model.DataDeleteRequest) error {
 		return err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	if err := api.client.ParseResponse(resp, nil); err != nil {
 		return err // 响应解析失败，返回错误
 	}
 
@@ -855,7 +854,8 @@ func (api *DatasetAPI) DeleteData(req *model.DataDeleteRequest) error {
 //
 // 参数：
 //
-//	req: 搜索测试请求，包含知识库ID、测试文本、搜索模式等
+//	req: 搜索测试请求，包含知识库ID、测试文本、搜索模式等；为空的字段会使用WithSearchConfig设置的默认值
+//	opts: 可选配置项，如WithCollectionInfo、WithTopK
 //
 // 返回值：
 //
@@ -874,31 +874,131 @@ func (api *DatasetAPI) DeleteData(req *model.DataDeleteRequest) error {
 //	    UsingReRank:  false,
 //	}
 //	searchResults, err := datasetAPI.SearchTest(req)
-func (api *DatasetAPI) SearchTest(req *model.DatasetSearchTestRequest) ([]model.DatasetSearchTestResult, error) {
-	resp, err := api.client.DoRequest("POST", "/api/core/dataset/searchTest", req)
+//
+//	// 同时获取每条结果所属的集合信息，用于展示引用来源
+//	searchResults, err := datasetAPI.SearchTest(req, dataset.WithCollectionInfo())
+func (api *DatasetAPI) SearchTest(req *model.DatasetSearchTestRequest, opts ...SearchTestOption) ([]model.DatasetSearchTestResult, error) {
+	var options searchTestOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx, cancel := client.ApplyCallOptions(context.Background(), options.callOpts...)
+	defer cancel()
+
+	searchReq := api.withSearchConfig(req)
+	if options.topK > 0 && searchReq.Limit < topKSearchLimit {
+		// 放宽token上限以取得足够多的候选结果，再在本地按分数截取
+		widened := *searchReq
+		widened.Limit = topKSearchLimit
+		searchReq = &widened
+	}
+
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/core/dataset/searchTest", searchReq)
 	if err != nil {
 		return nil, err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var searchResults []model.DatasetSearchTestResult
+	if err := api.client.ParseResponse(resp, &searchResults); err != nil {
 		return nil, err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为[]model.DatasetSearchTestResult类型
-	dataBytes, err := json.Marshal(baseResp.Data)
-	if err != nil {
-		return nil, err // 转换失败，返回错误
+	// 只保留分数最高的topK条结果
+	if options.topK > 0 {
+		sort.SliceStable(searchResults, func(i, j int) bool {
+			return searchResults[i].Score > searchResults[j].Score
+		})
+		if len(searchResults) > options.topK {
+			searchResults = searchResults[:options.topK]
+		}
 	}
 
-	var searchResults []model.DatasetSearchTestResult
-	if err := json.Unmarshal(dataBytes, &searchResults); err != nil {
-		return nil, err // 解析失败，返回错误
+	// 按需补充集合信息
+	if options.withCollection {
+		if err := api.fillCollections(searchResults); err != nil {
+			return nil, err // 获取集合信息失败，返回错误
+		}
 	}
 
 	return searchResults, nil // 返回搜索测试结果
 }
 
+// SearchTestOption 搜索测试可选配置
+type SearchTestOption func(*searchTestOptions)
+
+// searchTestOptions 搜索测试配置
+type searchTestOptions struct {
+	withCollection bool                // 是否补充集合信息
+	topK           int                 // 只返回分数最高的结果数量，0表示不限制
+	callOpts       []client.CallOption // 单次请求的配置
+}
+
+// topKSearchLimit 使用WithTopK时请求的token上限
+const topKSearchLimit = 20000
+
+// WithTopK 只返回相似度分数最高的k条结果
+//
+// 搜索接口的Limit是token上限而不是结果数量，不便于"取最相关的5条"这类检索评估场景。
+// 开启后请求的Limit会被放宽到至少20000，再按分数从高到低排序并截取前k条。
+//
+// 参数：
+//
+//	k: 返回的结果数量，小于等于0时不生效
+//
+// 注意事项：
+//   - 服务端仍会按自身的token上限截断结果，候选结果过长时可能不足k条
+//   - 与WithCollectionInfo同时使用时，只为截取后的结果补充集合信息
+func WithTopK(k int) SearchTestOption {
+	return func(o *searchTestOptions) {
+		o.topK = k
+	}
+}
+
+// WithCallOptions 为本次搜索测试请求设置单次请求配置
+//
+// 参数：
+//
+//	opts: 单次请求配置，如client.WithCallTimeout、client.WithCallHeader
+func WithCallOptions(opts ...client.CallOption) SearchTestOption {
+	return func(o *searchTestOptions) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
+}
+
+// WithCollectionInfo 为搜索结果补充所属集合信息
+//
+// 开启后会为每条结果调用GetCollectionDetail获取集合详情并填充到Collection字段，
+// 同一集合只请求一次，便于在界面中展示“结果来自文档X的第N段”。
+func WithCollectionInfo() SearchTestOption {
+	return func(o *searchTestOptions) {
+		o.withCollection = true
+	}
+}
+
+// fillCollections 为搜索结果填充集合信息，按集合ID缓存避免重复请求
+func (api *DatasetAPI) fillCollections(results []model.DatasetSearchTestResult) error {
+	cache := make(map[string]*model.CollectionInfo)
+	for i := range results {
+		collectionId := results[i].CollectionId
+		if collectionId == "" {
+			continue
+		}
+
+		info, ok := cache[collectionId]
+		if !ok {
+			var err error
+			info, err = api.GetCollectionDetail(collectionId)
+			if err != nil {
+				return err
+			}
+			cache[collectionId] = info
+		}
+		results[i].Collection = info
+	}
+	return nil
+}
+
 // CreateTrainOrder 创建训练订单
 //
 // 该方法用于创建训练订单，用于记录训练使用情况。
@@ -927,21 +1027,53 @@ func (api *DatasetAPI) CreateTrainOrder(req *model.DatasetTrainOrderRequest) (st
 		return "", err // 请求发送失败，返回错误
 	}
 
-	var baseResp model.BaseResponse
-	if err := api.client.ParseResponse(resp, &baseResp); err != nil {
+	var data json.RawMessage
+	if err := api.client.ParseResponse(resp, &data); err != nil {
 		return "", err // 响应解析失败，返回错误
 	}
 
-	// 将baseResp.Data转换为字符串类型的订单ID
-	dataBytes, err := json.Marshal(baseResp.Data)
+	// 兼容不同服务端版本返回的字符串ID和对象两种形式
+	orderId, err := parseID(data)
 	if err != nil {
-		return "", err // 转换失败，返回错误
-	}
-
-	var orderId string
-	if err := json.Unmarshal(dataBytes, &orderId); err != nil {
 		return "", err // 解析失败，返回错误
 	}
 
 	return orderId, nil // 返回训练订单ID
 }
+
+// normalizeParent 将指向空字符串的ParentId转换为nil，其他值原样返回
+//
+// 服务端可能原样保存空字符串的parentId，使知识库或集合落入列表中看不到的"幽灵根目录"，
+// 创建和列表方法在请求副本上调用该函数，按根目录处理。
+func normalizeParent(parentId *string) *string {
+	if model.IsRootParent(parentId) {
+		return nil
+	}
+	return parentId
+}
+
+// idFieldNames 对象形式的响应中可能携带ID的字段名，按优先级排列
+var idFieldNames = []string{"_id", "id", "datasetId", "collectionId", "billId"}
+
+// parseID 从创建类接口的data字段中提取ID
+//
+// 不同版本的服务端返回形式不一致：
+// - 字符串："6593e137231a2be9c5603ba7"
+// - 对象：{"_id":"6593e137231a2be9c5603ba7"}或{"datasetId":"..."}等
+func parseID(data json.RawMessage) (string, error) {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		return id, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", fmt.Errorf("无法从响应中解析ID: %s", string(data))
+	}
+	for _, name := range idFieldNames {
+		if id, ok := obj[name].(string); ok && id != "" {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("无法从响应中解析ID: %s", string(data))
+}
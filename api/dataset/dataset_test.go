@@ -0,0 +1,162 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "字符串", data: `"6593e137231a2be9c5603ba7"`, want: "6593e137231a2be9c5603ba7"},
+		{name: "对象_id", data: `{"_id":"d1"}`, want: "d1"},
+		{name: "对象datasetId", data: `{"datasetId":"d2"}`, want: "d2"},
+		{name: "对象collectionId", data: `{"collectionId":"c1","results":{}}`, want: "c1"},
+		{name: "对象中没有ID", data: `{"name":"x"}`, wantErr: true},
+		{name: "对象中ID为空", data: `{"_id":""}`, wantErr: true},
+		{name: "数组", data: `["d1"]`, wantErr: true},
+		{name: "格式错误", data: `{"_id":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseID(json.RawMessage(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseID(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseID(%s) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateDatasetResponseShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "字符串", data: "d1"},
+		{name: "对象", data: map[string]string{"datasetId": "d1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().OnData("POST", "/api/core/dataset/create", tt.data)
+			id, err := NewDatasetAPI(fake).CreateDataset(&model.DatasetCreateRequest{Name: "知识库"})
+			if err != nil {
+				t.Fatalf("CreateDataset() error = %v", err)
+			}
+			if id != "d1" {
+				t.Errorf("CreateDataset() = %q, want %q", id, "d1")
+			}
+		})
+	}
+}
+
+func TestCreateParentId(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name     string
+		parentId *string
+		want     string
+	}{
+		{name: "nil为根目录", parentId: model.RootParent(), want: `{"name":"知识库"}`},
+		{name: "指向空字符串按根目录处理", parentId: &empty, want: `{"name":"知识库"}`},
+		{name: "指向目录ID", parentId: model.Parent("f1"), want: `{"parentId":"f1","name":"知识库"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().OnData("POST", "/api/core/dataset/create", "d1")
+			req := &model.DatasetCreateRequest{ParentId: tt.parentId, Name: "知识库"}
+			if _, err := NewDatasetAPI(fake).CreateDataset(req); err != nil {
+				t.Fatalf("CreateDataset() error = %v", err)
+			}
+			if got := string(fake.Calls()[0].Body); got != tt.want {
+				t.Errorf("request body = %s, want %s", got, tt.want)
+			}
+			if req.ParentId != tt.parentId {
+				t.Error("CreateDataset() modified the caller's request")
+			}
+		})
+	}
+}
+
+func TestCreateCollectionParentId(t *testing.T) {
+	empty := ""
+	fake := clienttest.NewFake().
+		OnData("POST", "/api/core/dataset/collection/create", "c1").
+		OnData("POST", "/api/core/dataset/collection/create/text", map[string]string{"collectionId": "c2"}).
+		OnData("POST", "/api/core/dataset/collection/listV2", map[string]interface{}{"total": 0, "list": []interface{}{}})
+	api := NewDatasetAPI(fake)
+
+	if _, err := api.CreateCollection(&model.CollectionCreateRequest{
+		DatasetId: "d1", ParentId: &empty, Name: "集合", Type: model.CollectionTypeVirtual,
+	}); err != nil {
+		t.Fatalf("CreateCollection() error = %v", err)
+	}
+	if _, err := api.CreateTextCollection(&model.CollectionCreateTextRequest{
+		DatasetId: "d1", ParentId: &empty, Name: "文本", Text: "内容",
+	}); err != nil {
+		t.Fatalf("CreateTextCollection() error = %v", err)
+	}
+	if _, err := api.GetCollectionList(&model.CollectionListRequest{DatasetId: "d1", ParentId: &empty}); err != nil {
+		t.Fatalf("GetCollectionList() error = %v", err)
+	}
+
+	for _, call := range fake.Calls() {
+		var body map[string]interface{}
+		if err := json.Unmarshal(call.Body, &body); err != nil {
+			t.Fatalf("%s: invalid body %s", call.Path, call.Body)
+		}
+		if _, ok := body["parentId"]; ok {
+			t.Errorf("%s: body %s should omit parentId", call.Path, call.Body)
+		}
+	}
+}
+
+func TestPushDataEscapeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []client.Option
+		want string
+	}{
+		{name: "默认不转义", want: `"q":"<div>问题</div>"`},
+		{name: "WithEscapeHTML恢复转义", opts: []client.Option{client.WithEscapeHTML()}, want: `"q":"\u003cdiv\u003e问题\u003c/div\u003e"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"code":200,"statusText":"","message":"","data":{"insertLen":1}}`)
+			}))
+			defer server.Close()
+
+			api := NewDatasetAPI(client.NewClient(server.URL, "key", tt.opts...))
+			req := &model.DataPushRequest{CollectionId: "c1", TrainingType: "chunk", Data: []model.DatasetData{{Q: "<div>问题</div>"}}}
+			if _, err := api.PushData(req); err != nil {
+				t.Fatalf("PushData() error = %v", err)
+			}
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("request body = %s, want it to contain %s", body, tt.want)
+			}
+		})
+	}
+}
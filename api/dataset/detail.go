@@ -0,0 +1,78 @@
+package dataset
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// dataDetailConcurrency GetDataDetailBatch同时进行的请求数
+const dataDetailConcurrency = 8
+
+// GetDataDetailBatch 并发获取多条数据的详情
+//
+// 用于在搜索测试后补全结果的完整数据（索引、分块索引等），最多同时发送8个GetDataDetail请求，
+// 避免逐条串行请求的延迟。重复的ID只请求一次，空ID会被忽略。
+//
+// 参数：
+//
+//	ids: 数据ID列表
+//
+// 返回值：
+//
+//	map[string]*model.DatasetData: 以数据ID为键的数据详情，只包含获取成功的数据
+//	[]error: 每个失败ID对应的错误，错误信息包含数据ID；全部成功时为nil
+//
+// 使用示例：
+//
+//	results, _ := datasetAPI.SearchTest(req)
+//	ids := make([]string, 0, len(results))
+//	for _, r := range results {
+//	    ids = append(ids, r.ID)
+//	}
+//	details, errs := datasetAPI.GetDataDetailBatch(ids)
+//	for _, err := range errs {
+//	    log.Printf("获取数据详情失败: %v", err)
+//	}
+func (api *DatasetAPI) GetDataDetailBatch(ids []string) (map[string]*model.DatasetData, []error) {
+	seen := make(map[string]bool, len(ids))
+	jobs := make(chan string)
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			jobs <- id
+		}
+	}()
+
+	var (
+		mu      sync.Mutex
+		details = make(map[string]*model.DatasetData, len(ids))
+		errs    []error
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < dataDetailConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				data, err := api.GetDataDetail(&model.DataDetailRequest{Id: id})
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("数据 %s: %w", id, err))
+				} else {
+					details[id] = data
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return details, errs
+}
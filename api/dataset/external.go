@@ -0,0 +1,144 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedFileType 外部文件的类型不是FastGPT可以解析的文档类型
+//
+// 失效或过期的链接常常返回200和一个HTML错误页，也会得到该错误。
+var ErrUnsupportedFileType = errors.New("不支持的外部文件类型")
+
+// externalFileTypes FastGPT可以解析的外部文件类型，键为Content-Type
+var externalFileTypes = map[string]bool{
+	"application/pdf": true,
+	"text/plain":      true,
+	"text/markdown":   true,
+	"text/x-markdown": true,
+	"text/csv":        true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true, // docx
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true, // xlsx
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true, // pptx
+}
+
+// externalFileExts FastGPT可以解析的文件扩展名，用于Content-Type为通用二进制类型时按链接判断
+var externalFileExts = map[string]bool{
+	".pdf": true, ".txt": true, ".md": true, ".csv": true, ".docx": true, ".xlsx": true, ".pptx": true,
+}
+
+// externalFileClient 校验外部文件时使用的HTTP客户端
+//
+// 外部文件链接通常是公开或临时链接，与FastGPT服务无关，因此不携带API密钥。
+var externalFileClient = &http.Client{Timeout: 30 * time.Second}
+
+// ValidateExternalFile 校验外部文件链接是否可访问并返回文件元信息
+//
+// 该方法用于在调用CreateExternalFileCollection之前提前发现失效链接，
+// 避免集合创建成功但训练时才静默失败。优先发送HEAD请求，
+// 服务端不支持HEAD时回退为只请求第一个字节的Range GET请求。
+//
+// 链接可访问后还会校验文件类型：Content-Type须为pdf、txt、md、csv、docx、xlsx、pptx之一；
+// 对象存储常返回的application/octet-stream或空Content-Type按链接路径的扩展名判断。
+// 其他类型（如失效链接返回的text/html错误页）返回包装了ErrUnsupportedFileType的错误。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	fileURL: 外部文件访问链接
+//
+// 返回值：
+//
+//	contentType: 文件的Content-Type，服务端未返回时为空
+//	size: 文件大小（字节），无法获取时为-1
+//	err: 链接不可访问、返回非2xx状态码或文件类型不受支持时返回错误
+//
+// 使用示例：
+//
+//	contentType, size, err := dataset.ValidateExternalFile(ctx, "https://example.com/file.pdf")
+//	if err != nil {
+//	    log.Printf("外部文件不可用: %v", err)
+//	}
+func ValidateExternalFile(ctx context.Context, fileURL string) (contentType string, size int64, err error) {
+	resp, err := requestExternalFile(ctx, http.MethodHead, fileURL)
+	if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		contentType = resp.Header.Get("Content-Type")
+		return contentType, resp.ContentLength, checkExternalFileType(fileURL, contentType)
+	}
+	if err != nil && ctx.Err() != nil {
+		return "", -1, err // 上下文已取消，不再回退
+	}
+
+	// 部分服务端（如对象存储的签名链接）不支持HEAD，回退为Range GET
+	resp, err = requestExternalFile(ctx, http.MethodGet, fileURL)
+	if err != nil {
+		return "", -1, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", -1, fmt.Errorf("外部文件不可访问: %s (status: %d)", fileURL, resp.StatusCode)
+	}
+
+	contentType = resp.Header.Get("Content-Type")
+	return contentType, rangeTotalSize(resp), checkExternalFileType(fileURL, contentType)
+}
+
+// checkExternalFileType 校验文件类型是否为FastGPT可以解析的类型
+func checkExternalFileType(fileURL, contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "" // 缺失或无法解析时按扩展名判断
+	}
+	if externalFileTypes[mediaType] {
+		return nil
+	}
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		if u, err := url.Parse(fileURL); err == nil && externalFileExts[strings.ToLower(path.Ext(u.Path))] {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s (Content-Type: %q)", ErrUnsupportedFileType, fileURL, contentType)
+}
+
+// requestExternalFile 发送请求并立即关闭响应体，只保留响应头
+func requestExternalFile(ctx context.Context, method, fileURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0") // 只请求第一个字节
+	}
+
+	resp, err := externalFileClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	return resp, nil
+}
+
+// rangeTotalSize 从Range响应中获取文件总大小
+//
+// 206响应的Content-Range格式为"bytes 0-0/12345"；服务端忽略Range返回200时使用Content-Length。
+func rangeTotalSize(resp *http.Response) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		contentRange := resp.Header.Get("Content-Range")
+		if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+			if total, err := strconv.ParseInt(contentRange[i+1:], 10, 64); err == nil {
+				return total
+			}
+		}
+		return -1
+	}
+	return resp.ContentLength
+}
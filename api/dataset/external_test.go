@@ -0,0 +1,82 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateExternalFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/manual.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", "1234")
+	})
+	mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+		// 模拟不支持HEAD的签名链接
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Range") != "bytes=0-0" {
+			t.Errorf("Range = %q, want bytes=0-0", r.Header.Get("Range"))
+		}
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Range", "bytes 0-0/5678")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("#"))
+	})
+	mux.HandleFunc("/expired.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html>链接已过期</html>"))
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", "2048")
+	})
+	mux.HandleFunc("/missing.pdf", http.NotFound)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantType string
+		wantSize int64
+		wantErr  error // 为nil时期望成功
+	}{
+		{name: "HEAD返回文件信息", path: "/manual.pdf", wantType: "application/pdf", wantSize: 1234},
+		{name: "HEAD返回405时回退为Range GET", path: "/signed", wantType: "text/markdown; charset=utf-8", wantSize: 5678},
+		{name: "通用二进制类型按扩展名判断", path: "/files/report.docx", wantType: "application/octet-stream", wantSize: 2048},
+		{name: "返回HTML错误页", path: "/expired.pdf", wantErr: ErrUnsupportedFileType},
+		{name: "通用二进制类型且扩展名未知", path: "/files/archive.zip", wantErr: ErrUnsupportedFileType},
+		{name: "链接不存在", path: "/missing.pdf", wantErr: errAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, size, err := ValidateExternalFile(context.Background(), server.URL+tt.path)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("ValidateExternalFile() error = nil, want error")
+				}
+				if tt.wantErr != errAny && !errors.Is(err, tt.wantErr) {
+					t.Errorf("ValidateExternalFile() error = %v, want %v", err, tt.wantErr)
+				}
+				if errors.Is(err, ErrUnsupportedFileType) && !strings.Contains(err.Error(), contentType) {
+					t.Errorf("error %q should name the Content-Type %q", err, contentType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateExternalFile() error = %v", err)
+			}
+			if contentType != tt.wantType || size != tt.wantSize {
+				t.Errorf("ValidateExternalFile() = %q, %d, want %q, %d", contentType, size, tt.wantType, tt.wantSize)
+			}
+		})
+	}
+}
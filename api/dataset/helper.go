@@ -0,0 +1,683 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// collectionListPageSize 分页遍历集合列表时每页的数量，接口允许的最大值为30
+const collectionListPageSize = 30
+
+// CreateOption 创建知识库或集合的可选配置
+type CreateOption func(*createOptions)
+
+// createOptions 创建知识库或集合的配置
+type createOptions struct {
+	ifNotExists bool // 同名资源已存在时是否跳过创建
+}
+
+// WithCreateIfNotExists 同名资源已存在时直接返回已有资源，不再重复创建
+//
+// FastGPT接口不支持幂等键，该选项在客户端实现去重：创建前先在同一父级目录下按名称查找，
+// 找到时返回已有资源的ID。适用于"至少执行一次"的任务在网络重试时避免产生重复数据。
+//
+// 注意事项：
+//   - 去重是尽力而为的，查询和创建之间没有锁，多个进程并发创建同名资源时仍可能重复
+//   - 同名资源有多个时返回第一个
+func WithCreateIfNotExists() CreateOption {
+	return func(o *createOptions) {
+		o.ifNotExists = true
+	}
+}
+
+// applyCreateOptions 应用创建选项
+func applyCreateOptions(opts []CreateOption) createOptions {
+	var options createOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// findDatasetByName 查找指定目录下名称和类型完全匹配的知识库ID，不存在时返回空字符串
+//
+// datasetType为空时按普通知识库（dataset）匹配。
+func (api *DatasetAPI) findDatasetByName(parentId *string, name, datasetType string) (string, error) {
+	if datasetType == "" {
+		datasetType = "dataset"
+	}
+
+	req := &model.DatasetListRequest{}
+	if parentId != nil {
+		req.ParentId = *parentId
+	}
+	list, err := api.GetDatasetList(req)
+	if err != nil {
+		return "", err
+	}
+
+	for _, info := range list {
+		if info.Name == name && (info.Type == datasetType || info.Type == "" && datasetType == "dataset") {
+			return info.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// EnsureFolderPath 确保知识库文件夹路径存在并返回最末级文件夹的ID
+//
+// 从根目录开始逐级查找同名文件夹，不存在时创建，已存在的文件夹会被复用，
+// 因此可以重复调用。返回的ID可作为DatasetCreateRequest.ParentId使用。
+//
+// 参数：
+//
+//	path: 文件夹路径，如[]string{"Docs", "2024"}
+//
+// 返回值：
+//
+//	string: 最末级文件夹的ID，path为空时返回空字符串（即根目录）
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	folderId, err := datasetAPI.EnsureFolderPath([]string{"Docs", "2024"})
+//	if err != nil {
+//	    return err
+//	}
+//	datasetId, err := datasetAPI.CreateDataset(&model.DatasetCreateRequest{
+//	    ParentId: model.Parent(folderId), // path为空时为nil，即根目录
+//	    Name:     "产品手册",
+//	})
+func (api *DatasetAPI) EnsureFolderPath(path []string) (string, error) {
+	var parentId *string
+	folderId := ""
+	for _, name := range path {
+		var err error
+		folderId, err = api.CreateDataset(&model.DatasetCreateRequest{
+			ParentId: parentId,
+			Type:     "folder",
+			Name:     name,
+		}, WithCreateIfNotExists())
+		if err != nil {
+			return "", fmt.Errorf("创建文件夹 %s 失败: %w", name, err)
+		}
+
+		id := folderId
+		parentId = &id
+	}
+	return folderId, nil
+}
+
+// maxFolderDepth 递归删除时允许的最大文件夹深度，防止异常数据导致无限递归
+const maxFolderDepth = 32
+
+// DeleteDatasetRecursive 删除知识库，文件夹会连同其中的知识库和子文件夹一起删除
+//
+// 服务端可能拒绝删除非空文件夹。该方法对文件夹类型的知识库先按深度优先删除其下的
+// 全部知识库和子文件夹，最后删除文件夹本身；普通知识库直接删除。
+//
+// 参数：
+//
+//	id: 知识库或文件夹ID，不能为空
+//
+// 返回值：
+//
+//	int: 已删除的知识库和文件夹数量（包含id本身），出错时为出错前已删除的数量
+//	error: 请求失败、id为空或数据异常时返回错误
+//
+// 注意事项：
+//   - 删除不可恢复，且失败时已删除的部分不会回滚
+//   - 为防止误删，列表中父级ID与当前文件夹不一致的知识库会被视为异常数据并中止删除
+//
+// 使用示例：
+//
+//	deleted, err := datasetAPI.DeleteDatasetRecursive("your-folder-id")
+//	if err != nil {
+//	    log.Printf("已删除%d个，删除失败: %v\n", deleted, err)
+//	}
+func (api *DatasetAPI) DeleteDatasetRecursive(id string) (int, error) {
+	// 空ID在列表接口中表示根目录，直接拒绝以免误删全部知识库
+	if id == "" {
+		return 0, errors.New("知识库ID不能为空")
+	}
+
+	info, err := api.GetDatasetDetail(&model.DatasetDetailRequest{Id: id})
+	if err != nil {
+		return 0, err // 获取知识库详情失败，返回错误
+	}
+
+	return api.deleteDatasetTree(info.ID, info.Type, 0)
+}
+
+// deleteDatasetTree 深度优先删除知识库及其子项，返回已删除的数量
+func (api *DatasetAPI) deleteDatasetTree(id, datasetType string, depth int) (int, error) {
+	deleted := 0
+	if datasetType == "folder" {
+		if depth >= maxFolderDepth {
+			return 0, fmt.Errorf("文件夹 %s 的层级超过 %d 层", id, maxFolderDepth)
+		}
+
+		children, err := api.GetDatasetList(&model.DatasetListRequest{ParentId: id})
+		if err != nil {
+			return 0, err
+		}
+		for _, child := range children {
+			if child.ID == id || child.ParentId == nil || *child.ParentId != id {
+				return deleted, fmt.Errorf("文件夹 %s 的子项 %s 父级ID不一致，已中止删除", id, child.ID)
+			}
+
+			n, err := api.deleteDatasetTree(child.ID, child.Type, depth+1)
+			deleted += n
+			if err != nil {
+				return deleted, err
+			}
+		}
+	}
+
+	if err := api.DeleteDataset(&model.DatasetDeleteRequest{Id: id}); err != nil {
+		return deleted, err
+	}
+	return deleted + 1, nil
+}
+
+// UpsertOption 按名称更新集合的可选配置
+type UpsertOption func(*upsertOptions)
+
+// upsertOptions 按名称更新集合的配置
+type upsertOptions struct {
+	deleteFirst bool // 是否先删除旧集合再创建新集合
+}
+
+// WithDeleteBeforeCreate 先删除同名旧集合再创建新集合
+//
+// 默认先创建新集合再删除旧集合，保证替换过程中知识库始终有可用数据；
+// 开启该选项后可避免短时间内新旧集合同时出现在搜索结果中，但替换期间会存在数据空档。
+func WithDeleteBeforeCreate() UpsertOption {
+	return func(o *upsertOptions) {
+		o.deleteFirst = true
+	}
+}
+
+// UpsertTextCollection 按名称创建或替换纯文本集合
+//
+// 该方法会先在同一知识库、同一父级目录下查找与req.Name同名的集合：
+// 不存在时直接创建；存在时创建新集合并删除所有同名旧集合，适用于文档同步任务的重复执行。
+//
+// 参数：
+//
+//	req: 纯文本集合创建请求，Name用于匹配已有集合
+//	opts: 可选配置项，如WithDeleteBeforeCreate
+//
+// 返回值：
+//
+//	*model.CollectionCreateResponse: 新集合的创建响应，包含新的集合ID
+//	error: 如果请求失败，返回错误信息
+//
+// 注意事项：
+// - 默认先创建后删除，如果删除旧集合失败，新集合已创建成功，返回的响应和错误同时非空
+//
+// 使用示例：
+//
+//	req := &model.CollectionCreateTextRequest{
+//	    Text:         "文档内容",
+//	    DatasetId:    "your-dataset-id",
+//	    Name:         "README.md",
+//	    TrainingType: "chunk",
+//	}
+//	createResp, err := datasetAPI.UpsertTextCollection(req)
+func (api *DatasetAPI) UpsertTextCollection(req *model.CollectionCreateTextRequest, opts ...UpsertOption) (*model.CollectionCreateResponse, error) {
+	var options upsertOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// 查找同名旧集合
+	existing, err := api.findCollectionsByName(req.DatasetId, req.ParentId, req.Name)
+	if err != nil {
+		return nil, err // 查询失败，返回错误
+	}
+
+	if options.deleteFirst && len(existing) > 0 {
+		if err := api.DeleteCollection(&model.CollectionDeleteRequest{CollectionIds: existing}); err != nil {
+			return nil, err // 删除旧集合失败，返回错误
+		}
+	}
+
+	createResp, err := api.CreateTextCollection(req)
+	if err != nil {
+		return nil, err // 创建新集合失败，返回错误
+	}
+
+	if !options.deleteFirst && len(existing) > 0 {
+		if err := api.DeleteCollection(&model.CollectionDeleteRequest{CollectionIds: existing}); err != nil {
+			return createResp, err // 新集合已创建，返回删除旧集合的错误
+		}
+	}
+
+	return createResp, nil // 返回新集合的创建结果
+}
+
+// findCollectionsByName 分页查找指定目录下与名称完全匹配的集合ID
+func (api *DatasetAPI) findCollectionsByName(datasetId string, parentId *string, name string) ([]string, error) {
+	var ids []string
+	for offset := 0; ; offset += collectionListPageSize {
+		listResp, err := api.GetCollectionList(&model.CollectionListRequest{
+			Offset:     offset,
+			PageSize:   collectionListPageSize,
+			DatasetId:  datasetId,
+			ParentId:   parentId,
+			SearchText: name,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range listResp.List {
+			// SearchText为模糊匹配，这里需要再按名称精确过滤
+			if info.Name == name {
+				ids = append(ids, info.ID)
+			}
+		}
+
+		if len(listResp.List) < collectionListPageSize || offset+len(listResp.List) >= listResp.Total {
+			return ids, nil
+		}
+	}
+}
+
+// DatasetExists 判断知识库是否存在
+//
+// 参数：
+//
+//	id: 知识库ID
+//
+// 返回值：
+//
+//	bool: 知识库是否存在
+//	error: 除"资源不存在"以外的错误，如网络错误、鉴权失败等
+//
+// 使用示例：
+//
+//	exists, err := datasetAPI.DatasetExists("your-dataset-id")
+func (api *DatasetAPI) DatasetExists(id string) (bool, error) {
+	_, err := api.GetDatasetDetail(&model.DatasetDetailRequest{Id: id})
+	return existsResult(err)
+}
+
+// CollectionExists 判断集合是否存在
+//
+// 参数：
+//
+//	id: 集合ID
+//
+// 返回值：
+//
+//	bool: 集合是否存在
+//	error: 除"资源不存在"以外的错误，如网络错误、鉴权失败等
+//
+// 使用示例：
+//
+//	exists, err := datasetAPI.CollectionExists("your-collection-id")
+func (api *DatasetAPI) CollectionExists(id string) (bool, error) {
+	_, err := api.GetCollectionDetail(id)
+	return existsResult(err)
+}
+
+// existsResult 将详情接口的错误转换为存在性判断结果
+func existsResult(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if client.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// AddIndex 为单条数据追加一个索引
+//
+// 该方法会先通过GetDataDetail获取当前的全部索引，追加新索引后整体写回，
+// 未修改的索引（包括服务端生成的default、question等索引）保持原样，不会丢失。
+//
+// 参数：
+//
+//	dataId: 数据ID
+//	idx: 要追加的索引，通常Type为custom
+//
+// 返回值：
+//
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	err := datasetAPI.AddIndex("your-data-id", model.Index{Type: "custom", Text: "自定义索引"})
+func (api *DatasetAPI) AddIndex(dataId string, idx model.Index) error {
+	data, err := api.GetDataDetail(&model.DataDetailRequest{Id: dataId})
+	if err != nil {
+		return err // 获取数据详情失败，返回错误
+	}
+
+	indexes := append(data.Indexes, idx)
+	return api.UpdateData(&model.DataUpdateRequest{
+		DataId:  dataId,
+		Indexes: indexes,
+	})
+}
+
+// RemoveIndex 删除单条数据中的指定索引
+//
+// 该方法会先通过GetDataDetail获取当前的全部索引，移除ID匹配的索引后整体写回，
+// 其余索引保持原样。
+//
+// 参数：
+//
+//	dataId: 数据ID
+//	indexId: 要删除的索引ID，对应Index.ID
+//
+// 返回值：
+//
+//	error: 如果请求失败、索引不存在或删除后没有剩余索引，返回错误信息
+//
+// 使用示例：
+//
+//	err := datasetAPI.RemoveIndex("your-data-id", "your-index-id")
+func (api *DatasetAPI) RemoveIndex(dataId, indexId string) error {
+	data, err := api.GetDataDetail(&model.DataDetailRequest{Id: dataId})
+	if err != nil {
+		return err // 获取数据详情失败，返回错误
+	}
+
+	indexes := make([]model.Index, 0, len(data.Indexes))
+	for _, idx := range data.Indexes {
+		if idx.ID != indexId {
+			indexes = append(indexes, idx)
+		}
+	}
+	if len(indexes) == len(data.Indexes) {
+		return fmt.Errorf("数据 %s 中不存在索引 %s", dataId, indexId)
+	}
+	if len(indexes) == 0 {
+		// 空索引列表会因omitempty被省略，服务端不会做任何修改
+		return fmt.Errorf("不能删除数据 %s 的最后一个索引", dataId)
+	}
+
+	return api.UpdateData(&model.DataUpdateRequest{
+		DataId:  dataId,
+		Indexes: indexes,
+	})
+}
+
+// ForbidCollection 禁用集合
+//
+// 禁用后集合中的数据不会参与搜索。
+//
+// 参数：
+//
+//	id: 集合ID
+//
+// 返回值：
+//
+//	error: 如果请求失败，返回错误信息
+func (api *DatasetAPI) ForbidCollection(id string) error {
+	return api.setCollectionForbid(id, true)
+}
+
+// EnableCollection 启用集合
+//
+// 显式发送"forbid":false，用于恢复被禁用的集合。
+//
+// 参数：
+//
+//	id: 集合ID
+//
+// 返回值：
+//
+//	error: 如果请求失败，返回错误信息
+func (api *DatasetAPI) EnableCollection(id string) error {
+	return api.setCollectionForbid(id, false)
+}
+
+// setCollectionForbid 设置集合的禁用状态
+func (api *DatasetAPI) setCollectionForbid(id string, forbid bool) error {
+	return api.UpdateCollection(&model.CollectionUpdateRequest{
+		ID:     id,
+		Forbid: &forbid,
+	})
+}
+
+// ErrRawTextUnavailable 集合没有可读取的原始文本，如文件夹或尚无数据的集合
+var ErrRawTextUnavailable = errors.New("集合没有可读取的原始文本")
+
+// dataListPageSize 分页遍历数据列表时每页的数量，接口允许的最大值为30
+const dataListPageSize = 30
+
+// GetCollectionRawText 获取集合的文本内容
+//
+// FastGPT没有提供直接返回集合原始文本的接口，该方法按分块索引顺序读取集合中的全部数据，
+// 将每条数据的主要数据和辅助数据（Q和A，A为空时只有Q）拼接为完整文本，
+// 可用于"查看原文"界面或使用新的分块参数重新训练。
+// 由于分块之间可能存在重叠或经过清洗，拼接结果与上传时的原文不保证逐字一致。
+//
+// 问答拆分（qa）模式的集合只保存了模型生成的问答对，无法还原原文，返回包装了ErrRawTextUnavailable的错误。
+//
+// 参数：
+//
+//	collectionId: 集合ID
+//
+// 返回值：
+//
+//	string: 按分块顺序拼接的文本
+//	error: 集合为文件夹、qa模式或没有数据时返回ErrRawTextUnavailable（可能被包装），请求失败时返回其他错误
+//
+// 使用示例：
+//
+//	text, err := datasetAPI.GetCollectionRawText("your-collection-id")
+//	if errors.Is(err, dataset.ErrRawTextUnavailable) {
+//	    // 集合没有文本内容
+//	}
+func (api *DatasetAPI) GetCollectionRawText(collectionId string) (string, error) {
+	info, err := api.GetCollectionDetail(collectionId)
+	if err != nil {
+		return "", err // 获取集合详情失败，返回错误
+	}
+	if info.Type == model.CollectionTypeFolder {
+		return "", ErrRawTextUnavailable
+	}
+	if info.TrainingType == "qa" {
+		return "", fmt.Errorf("%w: qa模式的集合只保存了生成的问答对", ErrRawTextUnavailable)
+	}
+
+	var rows []model.DatasetData
+	for offset := 0; ; offset += dataListPageSize {
+		listResp, err := api.GetDataList(&model.DataListRequest{
+			Offset:       offset,
+			PageSize:     dataListPageSize,
+			CollectionId: collectionId,
+		})
+		if err != nil {
+			return "", err // 获取数据列表失败，返回错误
+		}
+		rows = append(rows, listResp.List...)
+
+		if len(listResp.List) < dataListPageSize || len(rows) >= listResp.Total {
+			break
+		}
+	}
+	if len(rows) == 0 {
+		return "", ErrRawTextUnavailable
+	}
+
+	// 按分块索引恢复原文顺序
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].ChunkIndex < rows[j].ChunkIndex
+	})
+
+	chunks := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.A == "" {
+			chunks = append(chunks, row.Q)
+			continue
+		}
+		chunks = append(chunks, row.Q+"\n"+row.A)
+	}
+	return strings.Join(chunks, "\n"), nil
+}
+
+// ListRecentData 获取集合中更新时间不早于since的数据
+//
+// PushData只返回写入数量，不返回新数据的ID：推送的数据先进入训练队列，训练完成后才生成数据ID。
+// 需要引用刚推送的数据时，可以在推送前记录时间，等待训练完成（如WaitForTraining）后调用该方法取回数据及其ID。
+//
+// 参数：
+//
+//	collectionId: 集合ID
+//	since: 起始时间，更新时间早于该时间的数据会被忽略
+//
+// 返回值：
+//
+//	[]model.DatasetData: 符合条件的数据，顺序与数据列表接口一致
+//	error: 如果请求失败，返回错误信息
+//
+// 注意事项：
+//   - 推送时显式填写了UpdateTime的数据以填写的时间为准，可能早于推送时间
+//   - 该方法会遍历集合的全部数据，大集合请谨慎频繁调用
+//
+// 使用示例：
+//
+//	since := time.Now()
+//	_, err := datasetAPI.PushData(req)
+//	// ...等待训练完成
+//	rows, err := datasetAPI.ListRecentData("your-collection-id", since)
+//	for _, row := range rows {
+//	    fmt.Println(row.ID, row.Q)
+//	}
+func (api *DatasetAPI) ListRecentData(collectionId string, since time.Time) ([]model.DatasetData, error) {
+	var recent []model.DatasetData
+	for offset := 0; ; offset += dataListPageSize {
+		listResp, err := api.GetDataList(&model.DataListRequest{
+			Offset:       offset,
+			PageSize:     dataListPageSize,
+			CollectionId: collectionId,
+		})
+		if err != nil {
+			return nil, err // 获取数据列表失败，返回错误
+		}
+
+		for _, d := range listResp.List {
+			updated, err := model.ParseTime(d.UpdateTime)
+			if err == nil && !updated.Before(since) {
+				recent = append(recent, d)
+			}
+		}
+
+		if len(listResp.List) < dataListPageSize || offset+len(listResp.List) >= listResp.Total {
+			break
+		}
+	}
+	return recent, nil
+}
+
+// ErrRetrainUnsupported 集合类型不支持通过RetrainCollection重新训练
+var ErrRetrainUnsupported = errors.New("集合不支持重新训练")
+
+// RetrainCollection 使用新的分块参数重新训练集合
+//
+// FastGPT OpenAPI没有提供重新训练已有集合的接口，该方法通过"先创建、后删除"的方式实现：
+// 链接集合使用原链接重新创建，纯文本集合读取GetCollectionRawText拼接的文本后重新创建，
+// 新集合保留原名称、父级目录、训练模式、元数据和标签，确认新集合可以查询到之后才删除旧集合。
+//
+// 参数：
+//
+//	collectionId: 需要重新训练的集合ID
+//	chunkSize: 新的分块大小
+//	chunkSplitter: 新的自定义分割符，为空时不设置
+//
+// 返回值：
+//
+//	*model.CollectionCreateResponse: 新集合的创建响应，包含新的集合ID
+//	error: 集合类型不支持时返回包装了ErrRetrainUnsupported的错误，请求失败时返回其他错误
+//
+// 注意事项：
+//   - 只支持链接集合和纯文本（virtual）集合；文件、外部文件、API文件等集合无法在不丢失原文件的情况下重建，
+//     qa模式的纯文本集合无法还原原文，均返回ErrRetrainUnsupported，不会修改原集合
+//   - 重新训练后集合ID会改变，引用旧集合ID的外部数据需要同步更新
+//   - 纯文本集合的文本由分块拼接而成，与上传时的原文不保证逐字一致
+//   - 如果新集合创建后更新、校验或删除旧集合失败，返回的响应和错误同时非空，此时旧集合仍然保留
+//
+// 使用示例：
+//
+//	createResp, err := datasetAPI.RetrainCollection("your-collection-id", 1000, "")
+func (api *DatasetAPI) RetrainCollection(collectionId string, chunkSize int, chunkSplitter string) (*model.CollectionCreateResponse, error) {
+	info, err := api.GetCollectionDetail(collectionId)
+	if err != nil {
+		return nil, err // 获取集合详情失败，返回错误
+	}
+
+	var createResp *model.CollectionCreateResponse
+	switch {
+	case info.Type == model.CollectionTypeLink && info.RawLink != "":
+		createResp, err = api.CreateLinkCollection(&model.CollectionCreateLinkRequest{
+			Link:             info.RawLink,
+			DatasetId:        info.DatasetIDString(),
+			ParentId:         info.ParentId,
+			TrainingType:     info.TrainingType,
+			ChunkSettingMode: "custom",
+			ChunkSize:        chunkSize,
+			ChunkSplitter:    chunkSplitter,
+			QAPrompt:         info.QAPrompt,
+			Metadata:         info.Metadata,
+		})
+	case info.Type == model.CollectionTypeVirtual && info.TrainingType != "qa":
+		text, textErr := api.GetCollectionRawText(collectionId)
+		if textErr != nil {
+			return nil, textErr // 读取集合文本失败，返回错误
+		}
+		createResp, err = api.CreateTextCollection(&model.CollectionCreateTextRequest{
+			Text:             text,
+			DatasetId:        info.DatasetIDString(),
+			ParentId:         info.ParentId,
+			Name:             info.Name,
+			TrainingType:     info.TrainingType,
+			ChunkSettingMode: "custom",
+			ChunkSize:        chunkSize,
+			ChunkSplitter:    chunkSplitter,
+			QAPrompt:         info.QAPrompt,
+			Metadata:         info.Metadata,
+		})
+	default:
+		return nil, fmt.Errorf("%w: 类型为%s、训练模式为%s", ErrRetrainUnsupported, info.Type, info.TrainingType)
+	}
+	if err != nil {
+		return nil, err // 创建新集合失败，返回错误
+	}
+
+	// 链接集合的名称由抓取结果决定，统一按原集合恢复名称和标签
+	if err := api.UpdateCollection(&model.CollectionUpdateRequest{
+		ID:   createResp.CollectionId,
+		Name: info.Name,
+		Tags: info.Tags,
+	}); err != nil {
+		return createResp, err // 新集合已创建，返回更新失败的错误
+	}
+
+	// 确认新集合可以查询到后再删除旧集合，避免数据丢失
+	created, err := api.GetCollectionDetail(createResp.CollectionId)
+	if err != nil {
+		return createResp, fmt.Errorf("校验新集合失败，未删除旧集合: %w", err)
+	}
+	if created.ID != createResp.CollectionId {
+		return createResp, fmt.Errorf("校验新集合失败，未删除旧集合: 查询到的集合ID为%q", created.ID)
+	}
+
+	if err := api.DeleteCollection(&model.CollectionDeleteRequest{CollectionIds: []string{collectionId}}); err != nil {
+		return createResp, err // 新集合已创建，返回删除旧集合的错误
+	}
+
+	return createResp, nil // 返回新集合的创建结果
+}
@@ -0,0 +1,172 @@
+package dataset
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestGetCollectionRawText(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    model.CollectionInfo
+		rows    []model.DatasetData
+		want    string
+		wantErr error
+	}{
+		{
+			name: "按分块顺序拼接Q和A",
+			info: model.CollectionInfo{ID: "c1", Type: model.CollectionTypeVirtual, TrainingType: "chunk"},
+			rows: []model.DatasetData{
+				{Q: "第二段", ChunkIndex: 1},
+				{Q: "第一段", A: "第一段的补充", ChunkIndex: 0},
+			},
+			want: "第一段\n第一段的补充\n第二段",
+		},
+		{
+			name:    "qa模式无法还原原文",
+			info:    model.CollectionInfo{ID: "c1", Type: model.CollectionTypeFile, TrainingType: "qa"},
+			rows:    []model.DatasetData{{Q: "问题", A: "答案"}},
+			wantErr: ErrRawTextUnavailable,
+		},
+		{
+			name:    "文件夹",
+			info:    model.CollectionInfo{ID: "c1", Type: model.CollectionTypeFolder},
+			wantErr: ErrRawTextUnavailable,
+		},
+		{
+			name:    "没有数据",
+			info:    model.CollectionInfo{ID: "c1", Type: model.CollectionTypeVirtual, TrainingType: "chunk"},
+			wantErr: ErrRawTextUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().
+				OnData("GET", "/api/core/dataset/collection/detail", tt.info).
+				OnData("POST", "/api/core/dataset/data/v2/list", model.DataListResponse{List: tt.rows, Total: len(tt.rows)})
+
+			got, err := NewDatasetAPI(fake).GetCollectionRawText("c1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCollectionRawText() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetCollectionRawText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrainCollection(t *testing.T) {
+	tests := []struct {
+		name       string
+		info       model.CollectionInfo
+		verifyCode int
+		wantErr    error
+		wantCreate bool
+		wantDelete bool
+	}{
+		{
+			name:       "纯文本集合重建后删除旧集合",
+			info:       model.CollectionInfo{ID: "old", Type: model.CollectionTypeVirtual, TrainingType: "chunk", Name: "手册", Metadata: map[string]interface{}{"source": "wiki"}},
+			wantCreate: true,
+			wantDelete: true,
+		},
+		{
+			name:       "新集合校验失败时保留旧集合",
+			info:       model.CollectionInfo{ID: "old", Type: model.CollectionTypeVirtual, TrainingType: "chunk", Name: "手册"},
+			verifyCode: 500,
+			wantErr:    errAny,
+			wantCreate: true,
+		},
+		{
+			name:    "文件集合不支持",
+			info:    model.CollectionInfo{ID: "old", Type: model.CollectionTypeFile, TrainingType: "chunk"},
+			wantErr: ErrRetrainUnsupported,
+		},
+		{
+			name:    "qa模式的纯文本集合不支持",
+			info:    model.CollectionInfo{ID: "old", Type: model.CollectionTypeVirtual, TrainingType: "qa"},
+			wantErr: ErrRetrainUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().
+				OnData("GET", "/api/core/dataset/collection/detail?id=old", tt.info).
+				OnData("POST", "/api/core/dataset/data/v2/list", model.DataListResponse{List: []model.DatasetData{{Q: "内容"}}, Total: 1}).
+				OnData("POST", "/api/core/dataset/collection/create/text", map[string]string{"collectionId": "new"}).
+				OnData("PUT", "/api/core/dataset/collection/update", nil).
+				OnData("POST", "/api/core/dataset/collection/delete", nil)
+			if tt.verifyCode != 0 {
+				fake.OnError("GET", "/api/core/dataset/collection/detail?id=new", tt.verifyCode, "error", "集合不存在")
+			} else {
+				fake.OnData("GET", "/api/core/dataset/collection/detail?id=new", model.CollectionInfo{ID: "new"})
+			}
+
+			_, err := NewDatasetAPI(fake).RetrainCollection("old", 1000, "")
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("RetrainCollection() error = %v", err)
+			case tt.wantErr == errAny && err == nil:
+				t.Fatal("RetrainCollection() error = nil, want error")
+			case tt.wantErr != nil && tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("RetrainCollection() error = %v, want %v", err, tt.wantErr)
+			}
+
+			created, deleted := false, false
+			for _, call := range fake.Calls() {
+				switch call.Path {
+				case "/api/core/dataset/collection/create/text":
+					created = true
+					if tt.info.Metadata != nil && !strings.Contains(string(call.Body), `"metadata":{"source":"wiki"}`) {
+						t.Errorf("create body = %s, want metadata copied", call.Body)
+					}
+				case "/api/core/dataset/collection/delete":
+					deleted = true
+				}
+			}
+			if created != tt.wantCreate || deleted != tt.wantDelete {
+				t.Errorf("created = %v, deleted = %v, want %v, %v", created, deleted, tt.wantCreate, tt.wantDelete)
+			}
+		})
+	}
+}
+
+// errAny 表示期望返回任意错误
+var errAny = errors.New("any error")
+
+func TestCollectionForbid(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(api *DatasetAPI) error
+		want string
+	}{
+		{name: "启用集合发送forbid为false", call: func(api *DatasetAPI) error { return api.EnableCollection("c1") }, want: `{"id":"c1","forbid":false}`},
+		{name: "禁用集合发送forbid为true", call: func(api *DatasetAPI) error { return api.ForbidCollection("c1") }, want: `{"id":"c1","forbid":true}`},
+		{
+			name: "Forbid为nil时省略",
+			call: func(api *DatasetAPI) error {
+				return api.UpdateCollection(&model.CollectionUpdateRequest{ID: "c1", Name: "集合"})
+			},
+			want: `{"id":"c1","name":"集合"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().OnData("PUT", "/api/core/dataset/collection/update", nil)
+			if err := tt.call(NewDatasetAPI(fake)); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if got := string(fake.Calls()[0].Body); got != tt.want {
+				t.Errorf("request body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
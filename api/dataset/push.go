@@ -0,0 +1,207 @@
+package dataset
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// pushBatchSize 单次PushData允许的最大数据条数
+const pushBatchSize = 200
+
+// PushOption 批量推送数据的可选配置
+type PushOption func(*pushOptions)
+
+// pushOptions 批量推送数据的配置
+type pushOptions struct {
+	concurrency int                                                 // 并发推送的批次数
+	onBatch     func(sent, total int, resp *model.DataPushResponse) // 每批完成后的进度回调
+}
+
+// WithPushConcurrency 设置并发推送的批次数
+//
+// 参数：
+//
+//	n: 同时进行的PushData请求数，小于1时按1处理
+func WithPushConcurrency(n int) PushOption {
+	return func(o *pushOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithOnBatch 设置每批推送完成后的进度回调
+//
+// 回调始终在调用PushDataInBatches的goroutine中依次执行，即使开启了并发推送，
+// 使用者也无需考虑并发安全问题。
+//
+// 参数：
+//
+//	fn: 进度回调，sent为已成功推送的条数，total为总条数，resp为本批次的推送结果
+func WithOnBatch(fn func(sent, total int, resp *model.DataPushResponse)) PushOption {
+	return func(o *pushOptions) {
+		o.onBatch = fn
+	}
+}
+
+// PushDataInBatches 按每批200条自动拆分并推送数据
+//
+// 该方法将req.Data按接口上限拆分为多个批次依次（或并发）调用PushData，
+// 并汇总所有批次的结果。任一批次失败后不再发送新的批次，返回已汇总的结果和第一个错误。
+//
+// 参数：
+//
+//	req: 数据推送请求，Data可以超过200条
+//	opts: 可选配置项，如WithPushConcurrency、WithOnBatch
+//
+// 返回值：
+//
+//	*model.DataPushResponse: 所有成功批次的汇总结果
+//	error: 如果任一批次失败，返回第一个错误
+//
+// 使用示例：
+//
+//	resp, err := datasetAPI.PushDataInBatches(req,
+//	    dataset.WithPushConcurrency(4),
+//	    dataset.WithOnBatch(func(sent, total int, _ *model.DataPushResponse) {
+//	        fmt.Printf("已推送 %d/%d\n", sent, total)
+//	    }),
+//	)
+func (api *DatasetAPI) PushDataInBatches(req *model.DataPushRequest, opts ...PushOption) (*model.DataPushResponse, error) {
+	options := pushOptions{concurrency: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.concurrency < 1 {
+		options.concurrency = 1
+	}
+
+	// 按接口上限拆分批次
+	var batches [][]model.DatasetData
+	for start := 0; start < len(req.Data); start += pushBatchSize {
+		end := start + pushBatchSize
+		if end > len(req.Data) {
+			end = len(req.Data)
+		}
+		batches = append(batches, req.Data[start:end])
+	}
+
+	type batchResult struct {
+		size int
+		resp *model.DataPushResponse
+		err  error
+	}
+
+	jobs := make(chan []model.DatasetData)
+	results := make(chan batchResult)
+	stop := make(chan struct{})
+
+	// 启动推送协程
+	var wg sync.WaitGroup
+	for i := 0; i < options.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				batchReq := *req
+				batchReq.Data = batch
+				resp, err := api.PushData(&batchReq)
+				results <- batchResult{size: len(batch), resp: resp, err: err}
+			}
+		}()
+	}
+
+	// 分发批次，出现错误后停止分发
+	go func() {
+		defer close(results)
+		defer wg.Wait()
+		defer close(jobs)
+		for _, batch := range batches {
+			select {
+			case jobs <- batch:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// 在当前goroutine中汇总结果并回调进度
+	total := &model.DataPushResponse{}
+	sent := 0
+	var firstErr error
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+				close(stop)
+			}
+			continue
+		}
+
+		sent += r.size
+		total.Add(*r.resp)
+
+		if options.onBatch != nil {
+			options.onBatch(sent, len(req.Data), r.resp)
+		}
+	}
+
+	return total, firstErr
+}
+
+// PushDataGrouped 按订单分组推送数据
+//
+// DataPushRequest的BillId作用于整个请求，该方法为每个分组单独推送，并使用分组键作为该组的BillId，
+// 便于按来源文档等维度拆分统计费用。每个分组内部复用PushDataInBatches的自动分批逻辑，
+// 分组按键名顺序依次推送，任一分组失败后不再推送后续分组。
+//
+// 参数：
+//
+//	req: 推送请求模板，提供CollectionId、TrainingType和Prompt，其Data和BillId会被忽略
+//	groups: 分组数据，键为BillId，值为该组的数据；键为空字符串的分组不关联订单
+//	opts: 每个分组推送时使用的可选配置项，如WithPushConcurrency、WithOnBatch
+//
+// 返回值：
+//
+//	*model.DataPushResponse: 所有成功推送数据的汇总结果
+//	error: 如果任一分组失败，返回包含该分组BillId的错误
+//
+// 使用示例：
+//
+//	billA, _ := datasetAPI.CreateTrainOrder(&model.DatasetTrainOrderRequest{DatasetId: datasetId, Name: "手册A"})
+//	billB, _ := datasetAPI.CreateTrainOrder(&model.DatasetTrainOrderRequest{DatasetId: datasetId, Name: "手册B"})
+//	resp, err := datasetAPI.PushDataGrouped(&model.DataPushRequest{
+//	    CollectionId: collectionId,
+//	    TrainingType: "chunk",
+//	}, map[string][]model.DatasetData{
+//	    billA: dataFromA,
+//	    billB: dataFromB,
+//	})
+func (api *DatasetAPI) PushDataGrouped(req *model.DataPushRequest, groups map[string][]model.DatasetData, opts ...PushOption) (*model.DataPushResponse, error) {
+	billIds := make([]string, 0, len(groups))
+	for billId := range groups {
+		billIds = append(billIds, billId)
+	}
+	sort.Strings(billIds)
+
+	total := &model.DataPushResponse{}
+	for _, billId := range billIds {
+		if len(groups[billId]) == 0 {
+			continue
+		}
+
+		groupReq := *req
+		groupReq.BillId = billId
+		groupReq.Data = groups[billId]
+		resp, err := api.PushDataInBatches(&groupReq, opts...)
+		if resp != nil {
+			total.Add(*resp)
+		}
+		if err != nil {
+			return total, fmt.Errorf("推送订单 %s 的数据失败: %w", billId, err)
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,104 @@
+package dataset
+
+import "github.com/xxjwxc/fastgpt/model"
+
+// defaultSearchPagerLimit 分页搜索的初始Limit，与model.NewSearchTest的默认值一致
+const defaultSearchPagerLimit = 1500
+
+// SearchPager 按递增的Limit分页读取搜索测试结果
+//
+// 搜索测试接口不支持偏移量分页，只会返回不超过Limit个token的结果。SearchPager每次将
+// Limit翻倍重新搜索，去掉之前已经返回过的结果，从而逐页读取更多结果，用于检索效果评估。
+// 由于每页都会重新执行一次完整搜索，结果较多时请合理设置最大Limit。
+//
+// 使用示例：
+//
+//	pager := datasetAPI.NewSearchPager(req, 20000)
+//	for pager.HasNext() {
+//	    page, err := pager.Next()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Printf("新增%d条结果，累计约%d tokens\n", len(page), pager.Tokens())
+//	}
+type SearchPager struct {
+	api      *DatasetAPI
+	req      model.DatasetSearchTestRequest
+	opts     []SearchTestOption
+	maxLimit int             // Limit的上限
+	seen     map[string]bool // 已返回的结果ID
+	tokens   int             // 已返回结果的估算token数
+	done     bool            // 是否已读取完毕
+}
+
+// NewSearchPager 创建搜索测试分页器
+//
+// 参数：
+//
+//	req: 搜索测试请求，Limit作为第一页的大小，为0时使用1500
+//	maxLimit: Limit的上限，达到上限或没有新结果时结束
+//	opts: 每次搜索使用的可选配置项，如WithCollectionInfo
+//
+// 返回值：
+//
+//	*SearchPager: 搜索测试分页器
+func (api *DatasetAPI) NewSearchPager(req *model.DatasetSearchTestRequest, maxLimit int, opts ...SearchTestOption) *SearchPager {
+	p := &SearchPager{
+		api:      api,
+		req:      *req,
+		opts:     opts,
+		maxLimit: maxLimit,
+		seen:     make(map[string]bool),
+	}
+	if p.req.Limit <= 0 {
+		p.req.Limit = defaultSearchPagerLimit
+	}
+	return p
+}
+
+// HasNext 判断是否还可能有下一页
+func (p *SearchPager) HasNext() bool {
+	return !p.done
+}
+
+// Next 读取下一页结果
+//
+// 返回值：
+//
+//	[]model.DatasetSearchTestResult: 本页新增的结果，保持搜索接口返回的顺序
+//	error: 搜索失败时返回错误，之后可以重试
+func (p *SearchPager) Next() ([]model.DatasetSearchTestResult, error) {
+	if p.done {
+		return nil, nil
+	}
+	last := p.req.Limit >= p.maxLimit // 已达到上限，本页为最后一页
+	if last {
+		p.req.Limit = p.maxLimit
+	}
+
+	results, err := p.api.SearchTest(&p.req, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var page []model.DatasetSearchTestResult
+	for _, result := range results {
+		if p.seen[result.ID] {
+			continue
+		}
+		p.seen[result.ID] = true
+		page = append(page, result)
+	}
+	if last || len(page) == 0 {
+		p.done = true // 扩大Limit后没有新结果，说明已经返回了全部结果
+	}
+
+	p.tokens += model.SearchTestResults(page).EstimateTokens()
+	p.req.Limit *= 2
+	return page, nil
+}
+
+// Tokens 返回已读取结果的估算token数量，可用于调整Limit
+func (p *SearchPager) Tokens() int {
+	return p.tokens
+}
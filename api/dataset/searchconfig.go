@@ -0,0 +1,66 @@
+package dataset
+
+import (
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// WithSearchConfig 为知识库设置默认检索参数
+//
+// FastGPT不在知识库上保存检索参数，开启后SearchTest（包括SearchPager）会在发送请求前，
+// 使用该知识库的配置填充请求中为零值的字段，避免每次搜索都重复指定搜索模式、相似度和重排等参数。
+// 填充在请求的副本上进行，不会修改调用方传入的请求。可以多次使用，为不同知识库分别配置。
+//
+// 参数：
+//
+//	datasetId: 知识库ID
+//	cfg: 默认检索参数，填充规则见model.SearchConfig.ApplyTo
+//
+// 使用示例：
+//
+//	fgpt.Dataset = dataset.NewDatasetAPI(fgpt.Client,
+//	    dataset.WithSearchConfig("manual-dataset-id", model.SearchConfig{
+//	        Limit:       5000,
+//	        Similarity:  0.5,
+//	        SearchMode:  "mixedRecall",
+//	        UsingReRank: true,
+//	    }),
+//	)
+//	results, err := fgpt.Dataset.SearchTest(&model.DatasetSearchTestRequest{
+//	    DatasetId: "manual-dataset-id",
+//	    Text:      "如何退款",
+//	})
+func WithSearchConfig(datasetId string, cfg model.SearchConfig) Option {
+	return func(api *DatasetAPI) {
+		if api.searchConfigs == nil {
+			api.searchConfigs = make(map[string]model.SearchConfig)
+		}
+		api.searchConfigs[datasetId] = cfg
+	}
+}
+
+// SearchConfig 获取知识库的默认检索参数
+//
+// 参数：
+//
+//	datasetId: 知识库ID
+//
+// 返回值：
+//
+//	model.SearchConfig: 通过WithSearchConfig设置的检索参数
+//	bool: 是否为该知识库设置了检索参数
+func (api *DatasetAPI) SearchConfig(datasetId string) (model.SearchConfig, bool) {
+	cfg, ok := api.searchConfigs[datasetId]
+	return cfg, ok
+}
+
+// withSearchConfig 知识库设置了默认检索参数时返回填充后的请求副本
+func (api *DatasetAPI) withSearchConfig(req *model.DatasetSearchTestRequest) *model.DatasetSearchTestRequest {
+	cfg, ok := api.searchConfigs[req.DatasetId]
+	if !ok {
+		return req
+	}
+
+	filled := *req
+	cfg.ApplyTo(&filled)
+	return &filled
+}
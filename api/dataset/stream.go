@@ -0,0 +1,69 @@
+package dataset
+
+import (
+	"context"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// StreamData 分页读取集合中的全部数据并逐条发送到通道
+//
+// 与一次性读取全部数据相比，该方法每次只在内存中保留一页数据，
+// 适用于导出数万条数据的大集合或内存受限的批处理任务。
+//
+// 参数：
+//
+//	ctx: 上下文，取消后停止读取和发送，用于调用方提前停止消费
+//	collectionId: 集合ID
+//	out: 接收数据的通道，方法返回前（包括出错和ctx取消）会关闭该通道
+//
+// 返回值：
+//
+//	error: 如果读取数据列表失败或ctx被取消，返回错误信息；已发送的数据不会撤回
+//
+// 注意事项：
+//   - 发送是阻塞的，调用方需要持续读取通道直到其关闭；提前停止读取时应取消ctx，否则该方法不会返回
+//   - 数据按接口返回的顺序发送，如需按分块顺序处理请使用ChunkIndex
+//
+// 使用示例：
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel() // 提前退出循环时让StreamData返回
+//	out := make(chan model.DatasetData, 64)
+//	errCh := make(chan error, 1)
+//	go func() { errCh <- datasetAPI.StreamData(ctx, "your-collection-id", out) }()
+//	for data := range out {
+//	    fmt.Println(data.Q)
+//	}
+//	if err := <-errCh; err != nil {
+//	    return err
+//	}
+func (api *DatasetAPI) StreamData(ctx context.Context, collectionId string, out chan<- model.DatasetData) error {
+	defer close(out)
+	api = api.WithContext(ctx) // 分页请求同样随ctx取消
+
+	sent := 0
+	for offset := 0; ; offset += dataListPageSize {
+		listResp, err := api.GetDataList(&model.DataListRequest{
+			Offset:       offset,
+			PageSize:     dataListPageSize,
+			CollectionId: collectionId,
+		})
+		if err != nil {
+			return err // 获取数据列表失败，返回错误
+		}
+
+		for _, data := range listResp.List {
+			select {
+			case out <- data:
+			case <-ctx.Done():
+				return ctx.Err() // 调用方已停止读取
+			}
+		}
+		sent += len(listResp.List)
+
+		if len(listResp.List) < dataListPageSize || sent >= listResp.Total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// dataPage 返回一页数据列表响应
+func dataPage(start, n, total int) model.DataListResponse {
+	list := make([]model.DatasetData, n)
+	for i := range list {
+		list[i] = model.DatasetData{ID: fmt.Sprintf("d%d", start+i)}
+	}
+	return model.DataListResponse{List: list, Total: total}
+}
+
+func TestStreamData(t *testing.T) {
+	fake := clienttest.NewFake().
+		OnData("POST", "/api/core/dataset/data/v2/list", dataPage(0, dataListPageSize, 40)).
+		OnData("POST", "/api/core/dataset/data/v2/list", dataPage(dataListPageSize, 10, 40))
+
+	out := make(chan model.DatasetData)
+	errCh := make(chan error, 1)
+	go func() { errCh <- NewDatasetAPI(fake).StreamData(context.Background(), "c1", out) }()
+
+	count := 0
+	for range out {
+		count++
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("StreamData() error = %v", err)
+	}
+	if count != 40 {
+		t.Errorf("StreamData() sent %d rows, want 40", count)
+	}
+}
+
+func TestStreamDataCanceled(t *testing.T) {
+	fake := clienttest.NewFake().
+		OnData("POST", "/api/core/dataset/data/v2/list", dataPage(0, dataListPageSize, 40))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan model.DatasetData)
+	errCh := make(chan error, 1)
+	go func() { errCh <- NewDatasetAPI(fake).StreamData(ctx, "c1", out) }()
+
+	// 读取一条后停止读取并取消，StreamData应返回并关闭通道
+	<-out
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Fatalf("StreamData() error = %v, want context.Canceled", err)
+	}
+	for range out {
+	}
+}
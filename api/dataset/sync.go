@@ -0,0 +1,426 @@
+package dataset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// defaultSyncExtensions SyncDirectory默认同步的文件扩展名
+var defaultSyncExtensions = []string{".txt", ".md", ".markdown"}
+
+// SyncOption 目录同步的可选配置
+type SyncOption func(*syncOptions)
+
+// syncOptions 目录同步的配置
+type syncOptions struct {
+	extensions   []string     // 需要同步的文件扩展名
+	parentId     *string      // 集合所在的目录ID
+	trainingType string       // 数据处理方式
+	wait         bool         // 是否等待训练完成
+	waitOpts     []WaitOption // 等待训练完成的配置
+	keepRemoved  bool         // 是否保留目录中已不存在的集合
+	stateStore   StateStore   // 同步进度存储
+	fileUpload   bool         // 是否上传文件创建集合
+}
+
+// syncHashMetadataKey 上传文件同步时，集合元数据中记录文件内容SHA-256的键
+const syncHashMetadataKey = "syncFileSha256"
+
+// WithSyncExtensions 设置需要同步的文件扩展名
+//
+// 参数：
+//
+//	exts: 文件扩展名，如".txt"、".md"，不区分大小写，默认为.txt、.md、.markdown
+func WithSyncExtensions(exts ...string) SyncOption {
+	return func(o *syncOptions) {
+		o.extensions = exts
+	}
+}
+
+// WithSyncParent 将集合同步到知识库中的指定目录，默认为根目录
+//
+// 参数：
+//
+//	parentId: 目录ID，可以通过EnsureFolderPath获取，为空表示根目录
+func WithSyncParent(parentId string) SyncOption {
+	return func(o *syncOptions) {
+		o.parentId = model.Parent(parentId)
+	}
+}
+
+// WithSyncTrainingType 设置新建集合的数据处理方式
+//
+// 参数：
+//
+//	trainingType: 数据处理方式：chunk, qa，默认为chunk
+func WithSyncTrainingType(trainingType string) SyncOption {
+	return func(o *syncOptions) {
+		o.trainingType = trainingType
+	}
+}
+
+// WithSyncWait 每个集合创建后等待训练完成再处理下一个文件
+//
+// 参数：
+//
+//	opts: 等待训练完成的配置，如WithWaitTimeout
+func WithSyncWait(opts ...WaitOption) SyncOption {
+	return func(o *syncOptions) {
+		o.wait = true
+		o.waitOpts = opts
+	}
+}
+
+// WithSyncKeepRemoved 保留目录中已不存在的文件对应的集合，不执行删除
+func WithSyncKeepRemoved() SyncOption {
+	return func(o *syncOptions) {
+		o.keepRemoved = true
+	}
+}
+
+// WithSyncStateStore 使用store记录同步进度，中断后再次同步时跳过已处理的文件
+//
+// 每处理完一个文件即在内存中记录其内容哈希，每处理syncCheckpointInterval个文件以及同步因出错或ctx取消
+// 而返回时保存到store。再次同步时，内容哈希与记录一致的文件直接计为未变化，不再请求服务端比较；
+// 同步全部完成后清空记录，下一次完整同步重新与服务端比较。
+//
+// 参数：
+//
+//	store: 进度存储，如NewMemoryStateStore、NewFileStateStore
+func WithSyncStateStore(store StateStore) SyncOption {
+	return func(o *syncOptions) {
+		o.stateStore = store
+	}
+}
+
+// WithSyncFileUpload 上传文件创建集合，而不是读取文件内容创建纯文本集合
+//
+// 适用于PDF、Word等需要由服务端解析的文件，需要同时通过WithSyncExtensions设置扩展名，
+// 如WithSyncExtensions(".pdf", ".docx", ".md")。上传后集合会被重命名为文件的相对路径，与纯文本模式一致。
+//
+// 服务端的HashRawText是解析后文本的哈希，与文件内容的哈希无法比较，因此该模式下文件内容的SHA-256
+// 记录在集合元数据的syncFileSha256字段中，再次同步时与之比较；没有该字段的同名集合（如纯文本模式创建的集合）
+// 视为内容已变化并重新上传。
+func WithSyncFileUpload() SyncOption {
+	return func(o *syncOptions) {
+		o.fileUpload = true
+	}
+}
+
+// syncCheckpointInterval 同步时每处理多少个文件保存一次进度
+const syncCheckpointInterval = 20
+
+// SyncDirectory 将本地目录同步到知识库，使知识库中的集合与目录中的文件保持一致
+//
+// 该方法遍历dir下扩展名匹配的文件，以相对路径（使用"/"分隔）作为集合名称，
+// 计算文件内容的SHA-256并与同名集合的HashRawText比较：
+// - 没有同名集合：创建纯文本集合，使用WithSyncFileUpload时上传文件创建集合
+// - 内容发生变化：先创建新集合再删除旧集合
+// - 内容未变化：跳过
+// - 目录中已不存在的文件对应的集合：删除（可通过WithSyncKeepRemoved关闭）
+//
+// 参数：
+//
+//	ctx: 上下文，作为整个同步过程（包括每个请求和等待训练）的总截止时间
+//	datasetId: 知识库ID
+//	dir: 本地目录路径
+//	opts: 可选配置项，如WithSyncExtensions、WithSyncParent、WithSyncWait
+//
+// 返回值：
+//
+//	*model.SyncResult: 各类操作的数量，出错时为出错前已完成的部分
+//	error: 读取文件、请求失败或ctx被取消时返回错误
+//
+// 注意事项：
+//   - 默认以纯文本集合的方式创建，只适用于文本类文件；PDF等文件请使用WithSyncFileUpload
+//   - 目标目录下不是由该方法创建的集合（文件夹除外）同样会参与比较，目录中不存在时会被删除，
+//     建议为同步单独使用一个知识库或目录
+//   - 任一文件失败后立即停止，已完成的操作不会回滚，再次同步会从当前状态继续；
+//     大目录可使用WithSyncStateStore记录进度，再次同步时跳过已处理的文件
+//
+// 使用示例：
+//
+//	result, err := datasetAPI.SyncDirectory(ctx, "your-dataset-id", "./docs",
+//	    dataset.WithSyncExtensions(".md"),
+//	    dataset.WithSyncWait(dataset.WithWaitTimeout(5*time.Minute)),
+//	    dataset.WithSyncStateStore(dataset.NewFileStateStore("./sync-state.json")),
+//	)
+//	if err != nil {
+//	    log.Printf("同步失败: %v\n", err)
+//	}
+//	fmt.Println(result.Summary())
+func (api *DatasetAPI) SyncDirectory(ctx context.Context, datasetId, dir string, opts ...SyncOption) (result *model.SyncResult, err error) {
+	options := syncOptions{
+		extensions:   defaultSyncExtensions,
+		trainingType: "chunk",
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	api = api.WithContext(ctx) // 所有请求共用ctx的截止时间
+
+	files, err := listSyncFiles(dir, options.extensions)
+	if err != nil {
+		return nil, err // 遍历目录失败，返回错误
+	}
+
+	existing, err := api.listCollections(datasetId, options.parentId)
+	if err != nil {
+		return nil, err // 获取集合列表失败，返回错误
+	}
+
+	// 按名称分组，文件夹不参与同步
+	byName := make(map[string][]model.CollectionInfo)
+	for _, info := range existing {
+		if info.Type == model.CollectionTypeFolder {
+			continue
+		}
+		byName[info.Name] = append(byName[info.Name], info)
+	}
+
+	state := make(map[string]string)
+	if options.stateStore != nil {
+		loaded, loadErr := options.stateStore.Load()
+		if loadErr != nil {
+			return nil, loadErr // 读取同步进度失败，返回错误
+		}
+		for name, hash := range loaded {
+			state[name] = hash
+		}
+
+		// 返回前保存进度，同步全部完成时清空
+		defer func() {
+			if err == nil {
+				state = map[string]string{}
+			}
+			if saveErr := options.stateStore.Save(state); saveErr != nil {
+				err = errors.Join(err, saveErr)
+			}
+		}()
+	}
+
+	result = &model.SyncResult{}
+	for i, name := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if options.stateStore != nil && i > 0 && i%syncCheckpointInterval == 0 {
+			if err := options.stateStore.Save(state); err != nil {
+				return result, err // 保存同步进度失败，返回错误
+			}
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		hash, err := hashFile(path)
+		if err != nil {
+			return result, err // 读取文件失败，返回错误
+		}
+
+		old := byName[name]
+		delete(byName, name)
+
+		// 上次中断前已处理且内容未变化的文件
+		if state[name] == hash {
+			result.Unchanged++
+			continue
+		}
+
+		if len(old) == 1 {
+			unchanged, err := api.collectionHashEqual(old[0], hash, options.fileUpload)
+			if err != nil {
+				return result, err // 获取集合详情失败，返回错误
+			}
+			if unchanged {
+				state[name] = hash
+				result.Unchanged++
+				continue
+			}
+		}
+
+		collectionId, err := api.createSyncCollection(datasetId, path, name, hash, options)
+		if err != nil {
+			return result, err // 创建集合失败，返回错误
+		}
+		if options.wait {
+			if _, err := api.WaitForTraining(ctx, collectionId, options.waitOpts...); err != nil {
+				return result, err // 等待训练失败，返回错误
+			}
+		}
+
+		if len(old) == 0 {
+			state[name] = hash
+			result.Created++
+			continue
+		}
+
+		// 新集合创建成功后再删除旧集合，同名的多个旧集合一并删除
+		if err := api.DeleteCollection(&model.CollectionDeleteRequest{CollectionIds: collectionIDs(old)}); err != nil {
+			return result, err // 删除旧集合失败，返回错误
+		}
+		state[name] = hash
+		result.Updated++
+	}
+
+	if options.keepRemoved {
+		return result, nil
+	}
+
+	// 删除目录中已不存在的文件对应的集合
+	for _, infos := range byName {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := api.DeleteCollection(&model.CollectionDeleteRequest{CollectionIds: collectionIDs(infos)}); err != nil {
+			return result, err // 删除集合失败，返回错误
+		}
+		result.Deleted += len(infos)
+	}
+
+	return result, nil
+}
+
+// createSyncCollection 为文件创建集合并返回集合ID
+func (api *DatasetAPI) createSyncCollection(datasetId, path, name, hash string, options syncOptions) (string, error) {
+	if !options.fileUpload {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err // 读取文件失败，返回错误
+		}
+		createResp, err := api.CreateTextCollection(&model.CollectionCreateTextRequest{
+			Text:         string(content),
+			DatasetId:    datasetId,
+			ParentId:     options.parentId,
+			Name:         name,
+			TrainingType: options.trainingType,
+		})
+		if err != nil {
+			return "", err
+		}
+		return createResp.CollectionId, nil
+	}
+
+	createResp, err := api.CreateFileCollection(&model.CollectionCreateFileRequest{
+		FilePath:     path,
+		DatasetId:    datasetId,
+		ParentId:     options.parentId,
+		TrainingType: options.trainingType,
+		Metadata:     map[string]interface{}{syncHashMetadataKey: hash},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// 服务端以文件名命名集合，改为相对路径以便下次同步按名称匹配
+	if err := api.UpdateCollection(&model.CollectionUpdateRequest{ID: createResp.CollectionId, Name: name}); err != nil {
+		return "", err
+	}
+	return createResp.CollectionId, nil
+}
+
+// hashFile 计算文件内容的SHA-256
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// listSyncFiles 返回dir下扩展名匹配的文件相对路径，使用"/"分隔
+func listSyncFiles(dir string, extensions []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !hasExtension(path, extensions) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// hasExtension 判断文件扩展名是否在列表中，不区分大小写
+func hasExtension(path string, extensions []string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range extensions {
+		if strings.EqualFold(ext, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// listCollections 分页获取指定目录下的全部集合
+func (api *DatasetAPI) listCollections(datasetId string, parentId *string) ([]model.CollectionInfo, error) {
+	var list []model.CollectionInfo
+	for offset := 0; ; offset += collectionListPageSize {
+		listResp, err := api.GetCollectionList(&model.CollectionListRequest{
+			Offset:    offset,
+			PageSize:  collectionListPageSize,
+			DatasetId: datasetId,
+			ParentId:  parentId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, listResp.List...)
+
+		if len(listResp.List) < collectionListPageSize || offset+len(listResp.List) >= listResp.Total {
+			return list, nil
+		}
+	}
+}
+
+// collectionHashEqual 判断集合记录的文件哈希是否与hash相同
+//
+// 纯文本集合比较hashRawText，上传文件创建的集合比较元数据中的syncFileSha256。
+// 集合列表不一定返回这两个字段，缺失时再获取集合详情。
+func (api *DatasetAPI) collectionHashEqual(info model.CollectionInfo, hash string, fileUpload bool) (bool, error) {
+	stored := func(info model.CollectionInfo) string {
+		if !fileUpload {
+			return info.HashRawText
+		}
+		value, _ := info.Metadata[syncHashMetadataKey].(string)
+		return value
+	}
+
+	if stored(info) == "" {
+		detail, err := api.GetCollectionDetail(info.ID)
+		if err != nil {
+			return false, err
+		}
+		info = *detail
+	}
+	return stored(info) == hash, nil
+}
+
+// collectionIDs 提取集合ID列表
+func collectionIDs(infos []model.CollectionInfo) []string {
+	ids := make([]string, 0, len(infos))
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+	}
+	return ids
+}
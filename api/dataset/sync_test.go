@@ -0,0 +1,103 @@
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestSyncDirectoryFileUpload(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "guide"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("%PDF-1.4 手册")
+	if err := os.WriteFile(filepath.Join(dir, "guide", "manual.pdf"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		existing []model.CollectionInfo
+		detail   *model.CollectionInfo
+		want     model.SyncResult
+		upload   bool
+	}{
+		{
+			name: "新文件上传后重命名为相对路径",
+			want: model.SyncResult{Created: 1}, upload: true,
+		},
+		{
+			name: "元数据中的哈希一致时跳过",
+			existing: []model.CollectionInfo{{
+				ID: "c0", Name: "guide/manual.pdf", Type: "file",
+				Metadata: map[string]interface{}{syncHashMetadataKey: hash},
+			}},
+			want: model.SyncResult{Unchanged: 1},
+		},
+		{
+			name:     "列表中没有元数据时查询详情",
+			existing: []model.CollectionInfo{{ID: "c0", Name: "guide/manual.pdf", Type: "file"}},
+			detail: &model.CollectionInfo{
+				ID: "c0", Name: "guide/manual.pdf", Type: "file",
+				Metadata: map[string]interface{}{syncHashMetadataKey: hash},
+			},
+			want: model.SyncResult{Unchanged: 1},
+		},
+		{
+			name: "纯文本模式创建的同名集合重新上传",
+			existing: []model.CollectionInfo{{
+				ID: "c0", Name: "guide/manual.pdf", Type: "virtual", HashRawText: hash,
+				Metadata: map[string]interface{}{},
+			}},
+			detail: &model.CollectionInfo{ID: "c0", Name: "guide/manual.pdf", Type: "virtual", HashRawText: hash},
+			want:   model.SyncResult{Updated: 1}, upload: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().
+				OnData("POST", "/api/core/dataset/collection/listV2", map[string]interface{}{"total": len(tt.existing), "list": tt.existing}).
+				OnData("POST", "/api/core/dataset/collection/create/localFile", map[string]string{"collectionId": "c1"}).
+				OnData("PUT", "/api/core/dataset/collection/update", nil).
+				OnData("POST", "/api/core/dataset/collection/delete", nil)
+			if tt.detail != nil {
+				fake.OnData("GET", "/api/core/dataset/collection/detail", tt.detail)
+			}
+
+			result, err := NewDatasetAPI(fake).SyncDirectory(t.Context(), "d1", dir,
+				WithSyncExtensions(".pdf"), WithSyncFileUpload())
+			if err != nil {
+				t.Fatalf("SyncDirectory() error = %v", err)
+			}
+			if *result != tt.want {
+				t.Errorf("SyncDirectory() = %+v, want %+v", *result, tt.want)
+			}
+
+			uploaded := false
+			for _, call := range fake.Calls() {
+				switch call.Path {
+				case "/api/core/dataset/collection/create/localFile":
+					uploaded = true
+				case "/api/core/dataset/collection/update":
+					if got, want := string(call.Body), `{"id":"c1","name":"guide/manual.pdf"}`; got != want {
+						t.Errorf("update body = %s, want %s", got, want)
+					}
+				case "/api/core/dataset/collection/create/text":
+					t.Error("file upload mode created a text collection")
+				}
+			}
+			if uploaded != tt.upload {
+				t.Errorf("uploaded = %v, want %v", uploaded, tt.upload)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StateStore 目录同步进度存储接口
+//
+// 用于WithSyncStateStore，记录已处理文件的相对路径及其内容的SHA-256，
+// 使中断的SyncDirectory再次执行时可以跳过已处理的文件。
+type StateStore interface {
+	// Load 读取已保存的进度，没有进度时返回空map
+	Load() (map[string]string, error)
+	// Save 保存进度，覆盖之前的记录
+	Save(state map[string]string) error
+}
+
+// MemoryStateStore 内存中的同步进度存储，可以在多个goroutine中并发使用
+//
+// 进度只在当前进程内有效，适用于同一进程中因超时或取消而重试的同步。
+type MemoryStateStore struct {
+	mu    sync.Mutex
+	state map[string]string
+}
+
+// NewMemoryStateStore 创建内存中的同步进度存储
+//
+// 返回值：
+//
+//	*MemoryStateStore: 空的进度存储
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{state: make(map[string]string)}
+}
+
+// Load 实现StateStore接口，返回进度的副本
+func (s *MemoryStateStore) Load() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyState(s.state), nil
+}
+
+// Save 实现StateStore接口，保存进度的副本
+func (s *MemoryStateStore) Save(state map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = copyState(state)
+	return nil
+}
+
+// FileStateStore 以JSON文件保存的同步进度存储
+//
+// 进度在进程重启后仍然有效，适用于大目录的一次性迁移。
+// 保存时先写入临时文件再重命名，避免写入过程中中断导致文件损坏。
+type FileStateStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStateStore 创建以JSON文件保存的同步进度存储
+//
+// 参数：
+//
+//	path: 进度文件路径，文件不存在时视为没有进度
+//
+// 返回值：
+//
+//	*FileStateStore: 进度存储
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load 实现StateStore接口，从文件读取进度
+func (s *FileStateStore) Load() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]string), nil
+	}
+	if err != nil {
+		return nil, err // 读取进度文件失败，返回错误
+	}
+
+	state := make(map[string]string)
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err // 进度文件格式错误，返回错误
+	}
+	return state, nil
+}
+
+// Save 实现StateStore接口，将进度写入文件
+func (s *FileStateStore) Save(state map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err // 创建临时文件失败，返回错误
+	}
+	defer os.Remove(tmp.Name()) // 重命名成功后删除会失败，可以忽略
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// copyState 复制进度记录
+func copyState(state map[string]string) map[string]string {
+	copied := make(map[string]string, len(state))
+	for k, v := range state {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,250 @@
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// DataTags 数据的本地标签记录
+//
+// FastGPT的数据（DatasetData）没有标签或元数据字段，推送接口也不返回数据ID，
+// 因此标签只能在客户端记录：PushDataTagged按集合保存每条数据内容（Q和A）的哈希，
+// ListDataByTag、DeleteDataByTag再读取集合数据，按内容哈希匹配出带有该标签的数据。
+//
+// 持久化说明：
+//   - 记录只保存在内存中，进程退出后丢失；需要长期使用时，通过json.Marshal保存，
+//     下次启动后使用json.Unmarshal恢复
+//   - 数据在FastGPT页面上被编辑后内容哈希会改变，不再被匹配；内容相同的数据会被同时匹配
+//
+// DataTags可以在多个goroutine中并发使用，零值不可用，请使用NewDataTags创建。
+type DataTags struct {
+	mu      sync.RWMutex
+	entries map[string]map[string]map[string]bool // 标签 -> 集合ID -> 数据内容哈希
+}
+
+// NewDataTags 创建空的本地标签记录
+func NewDataTags() *DataTags {
+	return &DataTags{entries: make(map[string]map[string]map[string]bool)}
+}
+
+// Add 为集合中的数据添加标签
+//
+// 参数：
+//
+//	tag: 标签名
+//	collectionId: 数据所在的集合ID
+//	data: 需要添加标签的数据，只使用Q和A
+func (t *DataTags) Add(tag, collectionId string, data []model.DatasetData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	collections := t.entries[tag]
+	if collections == nil {
+		collections = make(map[string]map[string]bool)
+		t.entries[tag] = collections
+	}
+	hashes := collections[collectionId]
+	if hashes == nil {
+		hashes = make(map[string]bool)
+		collections[collectionId] = hashes
+	}
+	for _, d := range data {
+		hashes[dataHash(d)] = true
+	}
+}
+
+// Remove 删除标签的全部记录，不会删除FastGPT中的数据
+func (t *DataTags) Remove(tag string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, tag)
+}
+
+// Tags 返回全部标签名，按字典序排列
+func (t *DataTags) Tags() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tags := make([]string, 0, len(t.entries))
+	for tag := range t.entries {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// MarshalJSON 将标签记录序列化为JSON，用于持久化
+func (t *DataTags) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make(map[string]map[string][]string, len(t.entries))
+	for tag, collections := range t.entries {
+		out[tag] = make(map[string][]string, len(collections))
+		for collectionId, hashes := range collections {
+			list := make([]string, 0, len(hashes))
+			for hash := range hashes {
+				list = append(list, hash)
+			}
+			sort.Strings(list)
+			out[tag][collectionId] = list
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON 从MarshalJSON生成的JSON恢复标签记录，会替换现有记录
+func (t *DataTags) UnmarshalJSON(data []byte) error {
+	var in map[string]map[string][]string
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	entries := make(map[string]map[string]map[string]bool, len(in))
+	for tag, collections := range in {
+		entries[tag] = make(map[string]map[string]bool, len(collections))
+		for collectionId, list := range collections {
+			hashes := make(map[string]bool, len(list))
+			for _, hash := range list {
+				hashes[hash] = true
+			}
+			entries[tag][collectionId] = hashes
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = entries
+	return nil
+}
+
+// collections 返回标签涉及的集合及其数据哈希的副本
+func (t *DataTags) collections(tag string) map[string]map[string]bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make(map[string]map[string]bool, len(t.entries[tag]))
+	for collectionId, hashes := range t.entries[tag] {
+		copied := make(map[string]bool, len(hashes))
+		for hash := range hashes {
+			copied[hash] = true
+		}
+		out[collectionId] = copied
+	}
+	return out
+}
+
+// dataHash 计算数据内容的哈希，忽略首尾空白
+func dataHash(d model.DatasetData) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(d.Q) + "\x00" + strings.TrimSpace(d.A)))
+	return hex.EncodeToString(sum[:])
+}
+
+// PushDataTagged 推送数据并在本地记录标签
+//
+// 数据通过PushDataInBatches推送（自动分批），推送完成后在tags中为req.Data记录标签，
+// 之后可以通过ListDataByTag、DeleteDataByTag操作这部分数据。标签的持久化说明见DataTags。
+//
+// 参数：
+//
+//	req: 数据推送请求
+//	tags: 本地标签记录
+//	tag: 标签名
+//	opts: 可选配置项，如WithPushConcurrency
+//
+// 返回值：
+//
+//	*model.DataPushResponse: 推送结果
+//	error: 如果推送失败，返回错误信息；部分批次失败时已推送的数据同样会记录标签
+//
+// 使用示例：
+//
+//	tags := dataset.NewDataTags()
+//	_, err := datasetAPI.PushDataTagged(req, tags, "faq-2024")
+//	// 之后删除这一批数据
+//	deleted, err := datasetAPI.DeleteDataByTag(tags, "faq-2024")
+func (api *DatasetAPI) PushDataTagged(req *model.DataPushRequest, tags *DataTags, tag string, opts ...PushOption) (*model.DataPushResponse, error) {
+	resp, err := api.PushDataInBatches(req, opts...)
+
+	// 无法得知失败批次中哪些数据已写入，统一记录全部数据，未写入的数据不会被匹配到
+	tags.Add(tag, req.CollectionId, req.Data)
+	return resp, err
+}
+
+// ListDataByTag 获取带有指定标签的数据
+//
+// 逐页读取标签涉及的每个集合的全部数据，按内容哈希筛选，集合较大时会发送较多请求。
+// 数据训练完成后才会出现在数据列表中，刚推送的数据可能暂时无法列出。
+//
+// 参数：
+//
+//	tags: 本地标签记录
+//	tag: 标签名
+//
+// 返回值：
+//
+//	[]model.DatasetData: 带有该标签的数据
+//	error: 如果读取数据列表失败，返回错误信息
+func (api *DatasetAPI) ListDataByTag(tags *DataTags, tag string) ([]model.DatasetData, error) {
+	var matched []model.DatasetData
+	for collectionId, hashes := range tags.collections(tag) {
+		for offset := 0; ; offset += dataListPageSize {
+			listResp, err := api.GetDataList(&model.DataListRequest{
+				Offset:       offset,
+				PageSize:     dataListPageSize,
+				CollectionId: collectionId,
+			})
+			if err != nil {
+				return nil, err // 获取数据列表失败，返回错误
+			}
+
+			for _, d := range listResp.List {
+				if hashes[dataHash(d)] {
+					matched = append(matched, d)
+				}
+			}
+
+			if len(listResp.List) < dataListPageSize || offset+len(listResp.List) >= listResp.Total {
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// DeleteDataByTag 删除带有指定标签的数据
+//
+// 通过ListDataByTag找出数据后逐条删除，全部删除成功后移除本地的标签记录。
+//
+// 参数：
+//
+//	tags: 本地标签记录
+//	tag: 标签名
+//
+// 返回值：
+//
+//	int: 已删除的数据条数
+//	error: 如果读取或删除失败，返回错误信息，此时保留标签记录以便重试
+func (api *DatasetAPI) DeleteDataByTag(tags *DataTags, tag string) (int, error) {
+	data, err := api.ListDataByTag(tags, tag)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, d := range data {
+		if err := api.DeleteData(&model.DataDeleteRequest{Id: d.ID}); err != nil {
+			return deleted, err // 删除失败，返回已删除的数量
+		}
+		deleted++
+	}
+
+	tags.Remove(tag)
+	return deleted, nil
+}
@@ -0,0 +1,47 @@
+package dataset
+
+import (
+	"time"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// WithAutoTimestamps 自动填充请求中为空的时间字段
+//
+// 开启后，PushData（包括PushDataInBatches）会为UpdateTime为空的数据填充当前时间，
+// CreateExternalFileCollection会在CreateTime为空时填充当前时间，
+// 格式与model.FormatTime一致，避免手动拼写时间格式导致服务端拒绝请求。
+// 填充在请求的副本上进行，不会修改调用方传入的请求。
+//
+// 使用示例：
+//
+//	datasetAPI := dataset.NewDatasetAPI(c, dataset.WithAutoTimestamps())
+func WithAutoTimestamps() Option {
+	return func(api *DatasetAPI) {
+		api.autoTimestamps = true
+	}
+}
+
+// withPushTimestamps 开启自动填充时返回填充了UpdateTime的请求副本
+func (api *DatasetAPI) withPushTimestamps(req *model.DataPushRequest) *model.DataPushRequest {
+	if !api.autoTimestamps {
+		return req
+	}
+
+	filled := *req
+	filled.Data = make([]model.DatasetData, len(req.Data))
+	copy(filled.Data, req.Data)
+	filled.FillUpdateTime(time.Now())
+	return &filled
+}
+
+// withExternalFileTimestamps 开启自动填充时返回填充了CreateTime的请求副本
+func (api *DatasetAPI) withExternalFileTimestamps(req *model.CollectionCreateExternalFileRequest) *model.CollectionCreateExternalFileRequest {
+	if !api.autoTimestamps {
+		return req
+	}
+
+	filled := *req
+	filled.FillCreateTime(time.Now())
+	return &filled
+}
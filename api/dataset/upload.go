@@ -0,0 +1,148 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// CreateFileCollection 上传本地文件创建集合
+//
+// 该方法以multipart/form-data格式上传文件，文件内容以流的方式发送，不会整体读入内存。
+// 设置req.OnUploadProgress后，每次发送文件内容都会回调已发送的字节数和文件大小，可用于显示上传进度条。
+//
+// 参数：
+//
+//	req: 本地文件集合创建请求，包含文件路径、知识库ID和训练参数
+//	opts: 单次请求配置，如client.WithCallTimeout，上传大文件时应设置比默认30秒更长的超时
+//
+// 返回值：
+//
+//	*model.CollectionCreateResponse: 集合创建响应，包含创建的集合ID和处理结果
+//	error: 如果文件无法读取或请求失败，返回错误信息
+//
+// 接口文档：https://doc.fastgpt.cn/docs/introduction/development/openapi/dataset#%E5%88%9B%E5%BB%BA%E4%B8%80%E4%B8%AA%E6%96%87%E4%BB%B6%E9%9B%86%E5%90%88
+//
+// 使用示例：
+//
+//	req := &model.CollectionCreateFileRequest{
+//	    FilePath:     "./docs/manual.pdf",
+//	    DatasetId:    "your-dataset-id",
+//	    TrainingType: "chunk",
+//	    OnUploadProgress: func(sent, total int64) {
+//	        fmt.Printf("\r已上传 %d/%d 字节", sent, total)
+//	    },
+//	}
+//	createResp, err := datasetAPI.CreateFileCollection(req, client.WithCallTimeout(10*time.Minute))
+func (api *DatasetAPI) CreateFileCollection(req *model.CollectionCreateFileRequest, opts ...client.CallOption) (*model.CollectionCreateResponse, error) {
+	normalized := *req
+	normalized.ParentId = normalizeParent(req.ParentId)
+	req = &normalized
+
+	info, err := os.Stat(req.FilePath)
+	if err != nil {
+		return nil, err // 文件不存在或无法访问，返回错误
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s 是目录，不能作为文件上传", req.FilePath)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err // 序列化失败，返回错误
+	}
+
+	total := int64(-1)
+	if info.Mode().IsRegular() {
+		total = info.Size()
+	}
+
+	ctx, cancel := client.ApplyCallOptions(context.Background(), opts...)
+	defer cancel()
+
+	body := newFileUploadBody(req.FilePath, data, total, req.OnUploadProgress)
+	resp, err := api.client.DoRequestContext(ctx, "POST", "/api/core/dataset/collection/create/localFile", body)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	var createResp model.CollectionCreateResponse
+	if err := api.client.ParseResponse(resp, &createResp); err != nil {
+		return nil, err // 响应解析失败，返回错误
+	}
+
+	api.invalidateListCache(req.DatasetId) // 新集合会出现在列表中
+
+	return &createResp, nil // 返回集合创建响应
+}
+
+// newFileUploadBody 创建上传文件的multipart请求体
+//
+// 每次Open都重新打开文件，由后台goroutine边读边写入管道，重试时从头上传。
+func newFileUploadBody(path string, data []byte, total int64, onProgress func(bytesSent, total int64)) *client.RawBody {
+	boundary := multipart.NewWriter(io.Discard)
+	return &client.RawBody{
+		ContentType: boundary.FormDataContentType(),
+		Open: func() (io.Reader, error) {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+
+			pr, pw := io.Pipe()
+			go func() {
+				defer file.Close()
+
+				mw := multipart.NewWriter(pw)
+				if err := mw.SetBoundary(boundary.Boundary()); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+				content := &progressReader{r: file, total: total, onProgress: onProgress}
+				pw.CloseWithError(writeFileForm(mw, filepath.Base(path), content, data))
+			}()
+			return pr, nil
+		},
+	}
+}
+
+// writeFileForm 写入data和file两个表单字段
+func writeFileForm(mw *multipart.Writer, filename string, content io.Reader, data []byte) error {
+	if err := mw.WriteField("data", string(data)); err != nil {
+		return err
+	}
+
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+// progressReader 读取时报告进度的Reader，onProgress为nil时不报告
+type progressReader struct {
+	r          io.Reader
+	sent       int64
+	total      int64
+	onProgress func(bytesSent, total int64)
+}
+
+// Read 读取数据并回调累计读取的字节数
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.sent += int64(n)
+	if n > 0 && p.onProgress != nil {
+		p.onProgress(p.sent, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,112 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestCreateFileCollection(t *testing.T) {
+	content := strings.Repeat("FastGPT知识库", 10000)
+	path := filepath.Join(t.TempDir(), "手册.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		onProgress bool
+	}{
+		{name: "报告上传进度", onProgress: true},
+		{name: "未设置进度回调", onProgress: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().OnData("POST", "/api/core/dataset/collection/create/localFile", map[string]string{"collectionId": "c1"})
+
+			var lastSent, lastTotal int64
+			calls := 0
+			req := &model.CollectionCreateFileRequest{FilePath: path, DatasetId: "d1", TrainingType: "chunk"}
+			if tt.onProgress {
+				req.OnUploadProgress = func(sent, total int64) {
+					if sent < lastSent {
+						t.Errorf("bytesSent decreased from %d to %d", lastSent, sent)
+					}
+					lastSent, lastTotal = sent, total
+					calls++
+				}
+			}
+
+			resp, err := NewDatasetAPI(fake).CreateFileCollection(req)
+			if err != nil {
+				t.Fatalf("CreateFileCollection() error = %v", err)
+			}
+			if resp.CollectionId != "c1" {
+				t.Errorf("CollectionId = %q, want %q", resp.CollectionId, "c1")
+			}
+			if tt.onProgress {
+				if calls == 0 {
+					t.Fatal("OnUploadProgress was not called")
+				}
+				if lastSent != int64(len(content)) || lastTotal != int64(len(content)) {
+					t.Errorf("last progress = %d/%d, want %d/%d", lastSent, lastTotal, len(content), len(content))
+				}
+			}
+
+			call := fake.Calls()[0]
+			_, params, err := mime.ParseMediaType(call.ContentType)
+			if err != nil {
+				t.Fatalf("invalid content type %q", call.ContentType)
+			}
+			form, err := multipart.NewReader(bytes.NewReader(call.Body), params["boundary"]).ReadForm(1 << 20)
+			if err != nil {
+				t.Fatalf("ReadForm() error = %v", err)
+			}
+
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(form.Value["data"][0]), &data); err != nil {
+				t.Fatalf("invalid data field %q", form.Value["data"])
+			}
+			if data["datasetId"] != "d1" || data["trainingType"] != "chunk" {
+				t.Errorf("data = %v", data)
+			}
+
+			file := form.File["file"][0]
+			if file.Filename != "手册.txt" {
+				t.Errorf("filename = %q, want %q", file.Filename, "手册.txt")
+			}
+			f, err := file.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			got, _ := io.ReadAll(f)
+			if string(got) != content {
+				t.Errorf("uploaded %d bytes, want %d", len(got), len(content))
+			}
+		})
+	}
+}
+
+func TestCreateFileCollectionMissingFile(t *testing.T) {
+	fake := clienttest.NewFake()
+	_, err := NewDatasetAPI(fake).CreateFileCollection(&model.CollectionCreateFileRequest{
+		FilePath: filepath.Join(t.TempDir(), "不存在.pdf"), DatasetId: "d1",
+	})
+	if !os.IsNotExist(err) {
+		t.Errorf("CreateFileCollection() error = %v, want not exist", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Error("request sent for a missing file")
+	}
+}
@@ -0,0 +1,317 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// ErrEmptyCollection 集合训练完成后没有任何数据，通常是链接无法访问或抓取内容为空
+var ErrEmptyCollection = errors.New("集合训练完成后没有数据")
+
+// 轮询训练状态的默认配置
+const (
+	defaultPollInterval    = time.Second      // 初始轮询间隔
+	defaultMaxPollInterval = 30 * time.Second // 最大轮询间隔
+	defaultWaitTimeout     = 10 * time.Minute // 默认等待超时时间
+)
+
+// WaitOption 等待训练完成的可选配置
+type WaitOption func(*waitOptions)
+
+// waitOptions 等待训练完成的配置
+type waitOptions struct {
+	interval    time.Duration // 初始轮询间隔
+	maxInterval time.Duration // 最大轮询间隔
+	timeout     time.Duration // 等待超时时间
+}
+
+// WithPollInterval 设置轮询间隔
+//
+// 轮询间隔从initial开始每次翻倍，最大不超过max。实际等待时间会在此基础上随机增减最多20%，
+// 避免同时等待的大量集合在同一时刻请求服务端。
+//
+// 参数：
+//
+//	initial: 初始轮询间隔，默认1秒，小于等于0时使用默认值
+//	max: 最大轮询间隔，默认30秒，小于initial时按initial处理
+func WithPollInterval(initial, max time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = initial
+		o.maxInterval = max
+	}
+}
+
+// WithWaitTimeout 设置等待超时时间
+//
+// 参数：
+//
+//	timeout: 等待超时时间，默认10分钟；也可以通过ctx控制
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WaitForTraining 等待集合训练完成
+//
+// 按带随机抖动的指数退避轮询集合详情，直到待训练数据量为0，返回训练完成后的集合信息。
+// 同时等待多个集合时请使用WaitForTrainingBatch，减少请求次数。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	collectionId: 集合ID
+//	opts: 可选配置项，如WithPollInterval、WithWaitTimeout
+//
+// 返回值：
+//
+//	*model.CollectionInfo: 训练完成后的集合信息
+//	error: 请求失败或等待超时时返回错误
+//
+// 使用示例：
+//
+//	info, err := datasetAPI.WaitForTraining(ctx, "your-collection-id", dataset.WithWaitTimeout(5*time.Minute))
+func (api *DatasetAPI) WaitForTraining(ctx context.Context, collectionId string, opts ...WaitOption) (*model.CollectionInfo, error) {
+	options := newWaitOptions(opts)
+
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+
+	api = api.WithContext(ctx)
+	interval := options.interval
+	for {
+		info, err := api.GetCollectionDetail(collectionId)
+		if err != nil {
+			return nil, err // 获取集合详情失败，返回错误
+		}
+		if info.TrainingAmount == 0 {
+			return info, nil // 训练完成
+		}
+
+		if err := sleepContext(ctx, jitter(interval)); err != nil {
+			return info, err
+		}
+		interval = options.next(interval)
+	}
+}
+
+// newWaitOptions 应用可选配置并修正无效的轮询间隔
+//
+// 轮询间隔为0时会不停地请求服务端，因此小于等于0时使用默认值；最大间隔不小于初始间隔。
+func newWaitOptions(opts []WaitOption) waitOptions {
+	options := waitOptions{
+		interval:    defaultPollInterval,
+		maxInterval: defaultMaxPollInterval,
+		timeout:     defaultWaitTimeout,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.interval <= 0 {
+		options.interval = defaultPollInterval
+	}
+	if options.maxInterval < options.interval {
+		options.maxInterval = options.interval
+	}
+	return options
+}
+
+// next 返回指数退避后的下一个轮询间隔，避免长时间训练时频繁请求
+func (o waitOptions) next(interval time.Duration) time.Duration {
+	interval *= 2
+	if interval > o.maxInterval {
+		interval = o.maxInterval
+	}
+	return interval
+}
+
+// jitter 在d的基础上随机增减最多20%
+func jitter(d time.Duration) time.Duration {
+	delta := int64(d) / 5
+	if delta <= 0 {
+		return d
+	}
+	return d - time.Duration(delta) + time.Duration(rand.Int64N(2*delta+1))
+}
+
+// sleepContext 等待d或ctx结束，ctx结束时返回ctx的错误
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WaitForTrainingBatch 等待多个集合训练完成
+//
+// 第一轮获取每个集合的详情以确定其所在的知识库和目录，之后每轮按(知识库, 父目录)分组，
+// 通过集合列表接口一次获取一组集合的训练进度，而不是每个集合单独请求详情，
+// 适合SyncDirectory等批量创建集合后的等待。轮询间隔与WaitForTraining相同，带有随机抖动。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	collectionIds: 集合ID列表
+//	opts: 可选配置项，如WithPollInterval、WithWaitTimeout
+//
+// 返回值：
+//
+//	map[string]*model.CollectionInfo: 以集合ID为键的最新集合信息，TrainingAmount为0表示训练完成；
+//	    出错时包含已获取到的集合信息
+//	error: 请求失败或等待超时时返回错误
+//
+// 注意事项：
+//   - 轮询时不使用WithListCache的缓存，避免读取到过期的训练进度
+//
+// 使用示例：
+//
+//	infos, err := datasetAPI.WaitForTrainingBatch(ctx, collectionIds, dataset.WithWaitTimeout(30*time.Minute))
+//	for id, info := range infos {
+//	    if info.TrainingAmount > 0 {
+//	        log.Printf("集合 %s 仍有 %d 条数据待训练", id, info.TrainingAmount)
+//	    }
+//	}
+func (api *DatasetAPI) WaitForTrainingBatch(ctx context.Context, collectionIds []string, opts ...WaitOption) (map[string]*model.CollectionInfo, error) {
+	options := newWaitOptions(opts)
+
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+
+	uncached := api.WithContext(ctx)
+	uncached.listCache = nil
+
+	// 第一轮逐个获取详情，确定集合所在的知识库和目录
+	infos := make(map[string]*model.CollectionInfo, len(collectionIds))
+	for _, id := range collectionIds {
+		if _, ok := infos[id]; ok {
+			continue
+		}
+		info, err := uncached.GetCollectionDetail(id)
+		if err != nil {
+			return infos, err // 获取集合详情失败，返回错误
+		}
+		infos[id] = info
+	}
+
+	interval := options.interval
+	for {
+		// 按所在的知识库和目录对仍在训练的集合分组
+		groups := make(map[collectionGroup][]string)
+		for id, info := range infos {
+			if info.TrainingAmount > 0 {
+				key := collectionGroup{datasetId: info.DatasetIDString()}
+				if info.ParentId != nil {
+					key.parentId = *info.ParentId
+				}
+				groups[key] = append(groups[key], id)
+			}
+		}
+		if len(groups) == 0 {
+			return infos, nil // 全部训练完成
+		}
+
+		if err := sleepContext(ctx, jitter(interval)); err != nil {
+			return infos, err
+		}
+		interval = options.next(interval)
+
+		for key, ids := range groups {
+			if err := uncached.refreshTraining(key, ids, infos); err != nil {
+				return infos, err
+			}
+		}
+	}
+}
+
+// collectionGroup 集合所在的知识库和父目录
+type collectionGroup struct {
+	datasetId string
+	parentId  string
+}
+
+// refreshTraining 通过集合列表更新一组集合的信息，列表中找不到的集合单独获取详情
+func (api *DatasetAPI) refreshTraining(key collectionGroup, ids []string, infos map[string]*model.CollectionInfo) error {
+	var parentId *string
+	if key.parentId != "" {
+		parentId = &key.parentId
+	}
+	list, err := api.listCollections(key.datasetId, parentId)
+	if err != nil {
+		return err // 获取集合列表失败，返回错误
+	}
+
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+	for i := range list {
+		if pending[list[i].ID] {
+			infos[list[i].ID] = &list[i]
+			delete(pending, list[i].ID)
+		}
+	}
+
+	for id := range pending {
+		info, err := api.GetCollectionDetail(id)
+		if err != nil {
+			return err // 获取集合详情失败，返回错误
+		}
+		infos[id] = info
+	}
+	return nil
+}
+
+// CreateLinkCollectionAndWait 创建链接集合并等待抓取和训练完成
+//
+// 链接集合创建接口会立即返回成功，但抓取失败（链接失效、超时等）要在之后才能发现。
+// 该方法在创建后轮询集合状态，训练完成后如果集合中没有任何数据，返回ErrEmptyCollection。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制创建请求和等待过程的超时和取消
+//	req: 链接集合创建请求
+//	opts: 可选配置项，如WithPollInterval、WithWaitTimeout
+//
+// 返回值：
+//
+//	*model.CollectionCreateResponse: 集合创建响应，创建成功后即使等待失败也会返回
+//	error: 创建失败、等待超时或集合为空时返回错误
+//
+// 使用示例：
+//
+//	createResp, err := datasetAPI.CreateLinkCollectionAndWait(ctx, req)
+//	if errors.Is(err, dataset.ErrEmptyCollection) {
+//	    // 链接抓取失败
+//	}
+func (api *DatasetAPI) CreateLinkCollectionAndWait(ctx context.Context, req *model.CollectionCreateLinkRequest, opts ...WaitOption) (*model.CollectionCreateResponse, error) {
+	createResp, err := api.WithContext(ctx).CreateLinkCollection(req)
+	if err != nil {
+		return nil, err // 创建集合失败，返回错误
+	}
+
+	info, err := api.WaitForTraining(ctx, createResp.CollectionId, opts...)
+	if err != nil {
+		return createResp, err // 等待失败，返回已创建的集合
+	}
+	if info.DataAmount == 0 {
+		return createResp, ErrEmptyCollection
+	}
+
+	return createResp, nil
+}
@@ -0,0 +1,58 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestNewWaitOptionsClampsInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []WaitOption
+		wantInterval time.Duration
+		wantMax      time.Duration
+	}{
+		{name: "默认值", wantInterval: defaultPollInterval, wantMax: defaultMaxPollInterval},
+		{name: "有效的间隔", opts: []WaitOption{WithPollInterval(2*time.Second, time.Minute)}, wantInterval: 2 * time.Second, wantMax: time.Minute},
+		{name: "间隔为0", opts: []WaitOption{WithPollInterval(0, 0)}, wantInterval: defaultPollInterval, wantMax: defaultPollInterval},
+		{name: "间隔为负数", opts: []WaitOption{WithPollInterval(-time.Second, time.Minute)}, wantInterval: defaultPollInterval, wantMax: time.Minute},
+		{name: "最大间隔小于初始间隔", opts: []WaitOption{WithPollInterval(5*time.Second, time.Second)}, wantInterval: 5 * time.Second, wantMax: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := newWaitOptions(tt.opts)
+			if options.interval != tt.wantInterval || options.maxInterval != tt.wantMax {
+				t.Errorf("newWaitOptions() interval = %v, max = %v, want %v, %v",
+					options.interval, options.maxInterval, tt.wantInterval, tt.wantMax)
+			}
+			// 退避后的间隔始终为正数且不超过最大间隔
+			interval := options.interval
+			for i := 0; i < 10; i++ {
+				interval = options.next(interval)
+				if interval <= 0 || interval > options.maxInterval {
+					t.Fatalf("next() = %v, want in (0, %v]", interval, options.maxInterval)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateLinkCollectionAndWaitCanceled(t *testing.T) {
+	fake := clienttest.NewFake().OnData("POST", "/api/core/dataset/collection/create/link", map[string]string{"collectionId": "c1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewDatasetAPI(fake).CreateLinkCollectionAndWait(ctx, &model.CollectionCreateLinkRequest{Link: "https://example.com", DatasetId: "d1"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CreateLinkCollectionAndWait() error = %v, want context.Canceled", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Error("create request sent with a canceled context")
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bytes"
+	"io"
+)
+
+// RawBody 不经过JSON序列化、原样发送的请求体
+//
+// 将*RawBody作为DoRequest、DoRequestContext或BuildRequest的body传入时，客户端使用Open返回的内容作为请求体，
+// 并以ContentType作为Content-Type请求头，用于上传文件等multipart请求。
+// 内容以流的方式发送，不会整体读入内存。
+//
+// 注意事项：
+//   - 401重试时会再次调用Open，Open每次都应返回从头开始的新内容
+//   - Open返回的内容实现了io.Closer时，请求结束后会被关闭
+//
+// 使用示例：
+//
+//	body := &client.RawBody{
+//	    ContentType: "text/plain",
+//	    Open: func() (io.Reader, error) {
+//	        return strings.NewReader("hello"), nil
+//	    },
+//	}
+//	resp, err := c.DoRequest("POST", "/api/xxx", body)
+type RawBody struct {
+	ContentType string                    // Content-Type请求头，如multipart.Writer.FormDataContentType()
+	Open        func() (io.Reader, error) // 返回请求体内容
+}
+
+// payload 编码后的请求体，重试时据此重新构建请求
+type payload struct {
+	json []byte   // JSON序列化后的请求体，无请求体时为nil
+	raw  *RawBody // 原样发送的请求体，不为nil时忽略json
+}
+
+// encodePayload 将DoRequest的body转换为payload
+func (c *Client) encodePayload(body interface{}) (payload, error) {
+	if raw, ok := body.(*RawBody); ok && raw != nil {
+		return payload{raw: raw}, nil
+	}
+	if body == nil {
+		return payload{}, nil
+	}
+
+	data, err := c.encodeBody(body)
+	if err != nil {
+		return payload{}, err // 序列化失败，返回错误
+	}
+	return payload{json: data}, nil
+}
+
+// open 返回请求体内容和Content-Type
+func (p payload) open() (io.Reader, string, error) {
+	if p.raw != nil {
+		r, err := p.raw.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		return r, p.raw.ContentType, nil
+	}
+	if p.json != nil {
+		return bytes.NewReader(p.json), "application/json", nil // 创建字节读取器
+	}
+	return nil, "application/json", nil
+}
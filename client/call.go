@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption 单次请求的可选配置
+//
+// 与Option作用于整个客户端不同，CallOption只对单次调用生效，
+// 用于为个别请求设置更长的超时、额外的请求头或指定上下文，而无需创建新的客户端。
+type CallOption func(*callOptions)
+
+// callOptions 单次请求的配置
+type callOptions struct {
+	ctx     context.Context // 请求使用的上下文
+	timeout time.Duration   // 请求超时时间
+	header  http.Header     // 额外的请求头
+}
+
+// callHeaderKey 上下文中保存单次请求头的键
+type callHeaderKey struct{}
+
+// callTimeoutKey 上下文中标记设置了单次超时的键
+type callTimeoutKey struct{}
+
+// WithCallTimeout 设置单次请求的超时时间
+//
+// 该超时时间会替代HTTPClient.Timeout（默认30秒），既可以缩短也可以延长，
+// 例如为耗时较长的对话请求单独设置5分钟超时。
+//
+// 参数：
+//
+//	timeout: 超时时间，小于等于0时忽略
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallHeader 为单次请求添加请求头
+//
+// 同名请求头会覆盖客户端默认设置的请求头（包括Authorization），
+// 但WithRequestHook注册的钩子在其之后执行，仍可以再次修改。
+//
+// 参数：
+//
+//	key: 请求头名称
+//	value: 请求头的值
+func WithCallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithCallContext 指定单次请求使用的上下文
+//
+// 用于不接收ctx参数的方法（如Chat、PushData）；对于接收ctx参数的方法，
+// 该选项会替代传入的ctx。
+//
+// 参数：
+//
+//	ctx: 上下文，为nil时忽略
+func WithCallContext(ctx context.Context) CallOption {
+	return func(o *callOptions) {
+		if ctx != nil {
+			o.ctx = ctx
+		}
+	}
+}
+
+// ApplyCallOptions 将单次请求的配置应用到上下文
+//
+// 返回的上下文应传给DoRequestContext，调用方需要在读取完响应后调用cancel释放资源。
+// 各API模块在内部调用该函数，一般不需要直接使用。
+//
+// 参数：
+//
+//	ctx: 基础上下文
+//	opts: 单次请求的配置
+//
+// 返回值：
+//
+//	context.Context: 应用配置后的上下文
+//	context.CancelFunc: 释放超时资源的函数，始终非nil
+func ApplyCallOptions(ctx context.Context, opts ...CallOption) (context.Context, context.CancelFunc) {
+	var options callOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.ctx != nil {
+		ctx = options.ctx
+	}
+	if len(options.header) > 0 {
+		ctx = context.WithValue(ctx, callHeaderKey{}, options.header)
+	}
+	if options.timeout > 0 {
+		ctx = context.WithValue(ctx, callTimeoutKey{}, true)
+		return context.WithTimeout(ctx, options.timeout)
+	}
+	return ctx, func() {}
+}
+
+// httpClientFor 返回发送该请求使用的HTTP客户端
+//
+// 设置了单次超时时，复制一个不带Timeout的客户端，由上下文控制超时，共用同一个Transport。
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	if ctx.Value(callTimeoutKey{}) == nil {
+		return c.HTTPClient
+	}
+	httpClient := *c.HTTPClient
+	httpClient.Timeout = 0
+	return &httpClient
+}
+
+// CallHeader 返回通过WithCallHeader设置到上下文中的单次请求头
+//
+// 用于实现了Doer接口的替身或包装器读取单次请求头，一般不需要直接使用。
+//
+// 参数：
+//
+//	ctx: ApplyCallOptions返回的上下文
+//
+// 返回值：
+//
+//	http.Header: 单次请求头，未设置时为nil
+func CallHeader(ctx context.Context) http.Header {
+	header, _ := ctx.Value(callHeaderKey{}).(http.Header)
+	return header
+}
+
+// applyCallHeader 将单次请求头设置到请求中
+func applyCallHeader(req *http.Request) {
+	header := CallHeader(req.Context())
+	if header == nil {
+		return
+	}
+	for key, values := range header {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
@@ -10,24 +10,69 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/xxjwxc/fastgpt/model"
 )
 
+// proApiPathPrefix 商业版接口的路径前缀
+const proApiPathPrefix = "/api/proApi/"
+
 // Client FastGPT HTTP客户端结构体，负责处理所有API请求和响应
 //
 // 该结构体封装了与FastGPT服务器通信的所有细节，包括请求构建、身份验证、
 // 超时设置和响应处理。
+//
+// 并发安全：Client可以在多个goroutine之间共享使用，每次请求的状态都保存在局部变量中，
+// 底层连接由带连接池的Transport复用。导出字段应在创建后、开始并发使用前设置，
+// 运行期间更换API密钥请使用SetAPIKey。
 type Client struct {
 	BaseURL    string       // FastGPT服务基础URL，例如：https://api.fastgpt.cn
 	APIKey     string       // API密钥，用于身份验证
 	HTTPClient *http.Client // 底层HTTP客户端，用于发送请求
 	Debug      bool         // 是否开启debug模式，开启后会打印HTTP请求和响应
+
+	tls              *tls.Config                              // 通过选项配置的TLS参数，为nil时使用默认配置
+	customHTTPClient bool                                     // 是否通过WithHTTPClient设置了自定义HTTP客户端
+	proxy            func(*http.Request) (*url.URL, error)    // 通过WithProxy设置的代理，为nil时读取环境变量
+	pool             *poolConfig                              // 通过WithTransportConfig设置的连接池参数，为nil时使用默认配置
+	escapeHTML       bool                                     // 序列化请求体时是否转义HTML字符
+	proApiBaseURL    string                                   // 商业版接口（/api/proApi/）的基础URL，为空时使用BaseURL
+	requestHooks     []func(*http.Request)                    // 请求发送前依次调用的钩子
+	responseHooks    []func(*http.Response, time.Duration)    // 响应体关闭时依次调用的钩子
+	logf             func(format string, args ...interface{}) // 通过WithLogger设置的debug日志函数，为nil时输出到标准输出
+
+	unauthorizedHandler func() (string, error) // 鉴权失败时刷新API密钥的函数
+	keyPool             *KeyPool               // 通过WithKeyPool设置的密钥池，为nil时使用APIKey
+	transportChanged    bool                   // 是否设置了需要重建Transport的选项，供Clone判断能否共享连接池
+	followRedirects     bool                   // 是否通过WithFollowRedirects开启了重定向跟随
+
+	mu sync.RWMutex // 保护APIKey在运行期间的并发读写
+
+	serverInfo   *model.ServerInfo // 缓存的服务端信息，见ServerInfo
+	serverInfoMu sync.Mutex        // 保护serverInfo的并发读写
+}
+
+// Doer 发送请求并解析响应的接口
+//
+// 各API模块依赖该接口而不是具体的*Client，*Client是其默认实现；
+// 单元测试中可以使用clienttest包提供的Fake替代真实的HTTP请求。
+type Doer interface {
+	// DoRequest 发送请求并返回HTTP响应
+	DoRequest(method, path string, body interface{}) (*http.Response, error)
+	// DoRequestContext 发送带上下文的请求并返回HTTP响应
+	DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error)
+	// ParseResponse 解析HTTP响应到v，并关闭响应体
+	ParseResponse(resp *http.Response, v interface{}) error
 }
 
 // NewClient 创建新的FastGPT HTTP客户端实例
@@ -36,6 +81,7 @@ type Client struct {
 //
 //	baseURL: FastGPT服务基础URL，例如：https://cloud.fastgpt.cn
 //	apiKey: API密钥，用于身份验证
+//	opts: 可选配置项，如WithHTTPClient、WithRootCAs等
 //
 // 返回值：
 //
@@ -44,8 +90,8 @@ type Client struct {
 // 使用示例：
 //
 //	c := client.NewClient("https://cloud.fastgpt.cn", "sk-xxx")
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
 		BaseURL: baseURL,
 		APIKey:  apiKey,
 		HTTPClient: &http.Client{
@@ -53,6 +99,122 @@ func NewClient(baseURL, apiKey string) *Client {
 		},
 		Debug: false, // 默认关闭debug模式
 	}
+
+	// 应用配置项
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applyTransport()
+	c.applyRedirectPolicy()
+
+	return c
+}
+
+// Close 关闭客户端持有的空闲连接
+//
+// 调用后客户端仍可继续使用，后续请求会重新建立连接。
+// 该方法是可选的，但在测试或短生命周期的命令行程序中推荐调用，
+// 避免保活连接延迟进程退出或在测试之间泄漏。
+//
+// 使用示例：
+//
+//	c := client.NewClient("https://cloud.fastgpt.cn", "sk-xxx")
+//	defer c.Close()
+func (c *Client) Close() {
+	c.HTTPClient.CloseIdleConnections()
+}
+
+// Clone 复制客户端并应用新的配置项
+//
+// 副本与原客户端相互独立，修改副本的APIKey、BaseURL等不会影响原客户端。
+// 未设置TLS、代理或连接池相关选项时，副本与原客户端共享底层Transport，从而复用同一个连接池；
+// 设置了这类选项时，副本会使用新建的Transport。
+//
+// 参数：
+//
+//	opts: 覆盖原配置的选项，如WithAPIKey、WithBaseURL
+//
+// 返回值：
+//
+//	*Client: 新的客户端实例
+//
+// 注意事项：
+// - 钩子、密钥刷新函数和密钥池（KeyPool）会被副本共享，需要隔离时请通过选项重新设置
+//
+// 使用示例：
+//
+//	tenantClient := c.Clone(client.WithAPIKey(tenant.APIKey))
+func (c *Client) Clone(opts ...Option) *Client {
+	httpClient := *c.HTTPClient
+	clone := &Client{
+		BaseURL:             c.BaseURL,
+		APIKey:              c.apiKey(),
+		HTTPClient:          &httpClient,
+		Debug:               c.Debug,
+		customHTTPClient:    c.customHTTPClient,
+		proxy:               c.proxy,
+		pool:                c.pool,
+		escapeHTML:          c.escapeHTML,
+		proApiBaseURL:       c.proApiBaseURL,
+		requestHooks:        append([]func(*http.Request){}, c.requestHooks...),
+		responseHooks:       append([]func(*http.Response, time.Duration){}, c.responseHooks...),
+		logf:                c.logf,
+		unauthorizedHandler: c.unauthorizedHandler,
+		keyPool:             c.keyPool,
+		followRedirects:     c.followRedirects,
+	}
+	if c.tls != nil {
+		clone.tls = c.tls.Clone() // 避免副本的TLS选项修改原客户端的配置
+	}
+
+	// 应用配置项
+	for _, opt := range opts {
+		opt(clone)
+	}
+	if clone.transportChanged {
+		clone.applyTransport()
+	}
+	clone.applyRedirectPolicy()
+
+	return clone
+}
+
+// SetAPIKey 并发安全地更新API密钥
+//
+// 参数：
+//
+//	apiKey: 新的API密钥，后续请求将使用该密钥
+func (c *Client) SetAPIKey(apiKey string) {
+	c.mu.Lock()
+	c.APIKey = apiKey
+	c.mu.Unlock()
+}
+
+// apiKey 并发安全地读取当前API密钥
+func (c *Client) apiKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.APIKey
+}
+
+// refreshAPIKey 鉴权失败后刷新API密钥
+//
+// 多个请求同时鉴权失败时只会调用一次刷新函数：如果密钥已被其他请求刷新，
+// 直接返回当前密钥。
+func (c *Client) refreshAPIKey(usedKey string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.APIKey != usedKey {
+		return c.APIKey, nil // 已被其他请求刷新
+	}
+
+	newKey, err := c.unauthorizedHandler()
+	if err != nil {
+		return "", err
+	}
+	c.APIKey = newKey
+	return newKey, nil
 }
 
 // DoRequest 发送HTTP请求到FastGPT服务器
@@ -61,7 +223,7 @@ func NewClient(baseURL, apiKey string) *Client {
 //
 //	method: HTTP方法，如"GET"、"POST"等
 //	path: API路径，如"/api/proApi/app/stats"
-//	body: 请求体数据，将被序列化为JSON格式
+//	body: 请求体数据，将被序列化为JSON格式；传入*RawBody时原样发送
 //
 // 返回值：
 //
@@ -69,35 +231,335 @@ func NewClient(baseURL, apiKey string) *Client {
 //	error: 如果请求发送失败，返回错误信息
 //
 // 处理流程：
-// 1. 如果请求体不为空，将其序列化为JSON格式
+// 1. 如果请求体不为空，将其序列化为JSON格式（默认不转义HTML字符）
 // 2. 创建HTTP请求，设置URL、方法和请求体
 // 3. 添加请求头，包括Authorization、Content-Type和User-Agent
 // 4. 发送请求并返回响应
+// 5. 如果返回401且设置了WithUnauthorizedHandler，刷新密钥后重试一次
+// 6. 如果设置了WithKeyPool，每次请求轮询使用密钥池中的密钥，返回401时移除该密钥并换一个密钥重试一次
 func (c *Client) DoRequest(method, path string, body interface{}) (*http.Response, error) {
-	var reqBody io.Reader
+	return c.DoRequestContext(context.Background(), method, path, body)
+}
+
+// DoRequestContext 发送带上下文的HTTP请求到FastGPT服务器
+//
+// 与DoRequest相同，但可以通过ctx控制请求的超时和取消。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	method: HTTP方法，如"GET"、"POST"等
+//	path: API路径
+//	body: 请求体数据，将被序列化为JSON格式
+//
+// 返回值：
+//
+//	*http.Response: HTTP响应对象，需要调用者处理响应体
+//	error: 如果请求发送失败，返回以"方法 路径: "开头的错误信息，如"POST /api/v1/chat/completions: ..."
+func (c *Client) DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	resp, err := c.doRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, wrapRequestError(method, path, err)
+	}
+	return resp, nil
+}
 
-	// 如果请求体不为空，将其序列化为JSON
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+// doRequest 序列化请求体并发送请求，处理密钥刷新和密钥池重试
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	// 如果请求体不为空，将其序列化为JSON，*RawBody原样发送
+	p, err := c.encodePayload(body)
+	if err != nil {
+		return nil, err // 序列化失败，返回错误
+	}
+
+	if c.keyPool != nil {
+		return c.doWithKeyPool(ctx, method, path, p)
+	}
+
+	key := c.apiKey()
+	resp, err := c.send(ctx, method, path, p, key)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	// 鉴权失败时刷新密钥并重试，每个请求只重试一次，避免无限循环
+	if resp.StatusCode == http.StatusUnauthorized && c.unauthorizedHandler != nil {
+		resp.Body.Close()
+
+		c.Debugf("fastgpt: retry 1/1 after 401, refreshing api key")
+		newKey, err := c.refreshAPIKey(key)
 		if err != nil {
-			return nil, err // 序列化失败，返回错误
+			return nil, fmt.Errorf("刷新API密钥失败: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody) // 创建字节缓冲区
+
+		return c.send(ctx, method, path, p, newKey)
 	}
 
-	// 创建HTTP请求
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	return resp, nil
+}
+
+// doWithKeyPool 从密钥池中取出密钥发送请求
+//
+// 返回401时将该密钥移出密钥池，并使用下一个密钥重试一次。
+func (c *Client) doWithKeyPool(ctx context.Context, method, path string, p payload) (*http.Response, error) {
+	key, err := c.keyPool.Next()
+	if err != nil {
+		return nil, err // 密钥池为空，返回错误
+	}
+
+	resp, err := c.send(ctx, method, path, p, key)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	// 移除失效密钥后换一个密钥重试
+	c.keyPool.Remove(key)
+	nextKey, err := c.keyPool.Next()
+	if err != nil {
+		return resp, nil // 没有其他密钥，返回原始的401响应
+	}
+	resp.Body.Close()
+
+	c.Debugf("fastgpt: retry 1/1 after 401, switching api key from pool")
+	return c.send(ctx, method, path, p, nextKey)
+}
+
+// Debugf 在debug模式下输出一行日志
+//
+// 使用WithLogger设置的日志函数，未设置时输出到标准输出；未开启Debug时不输出。
+// 各API模块通过该方法输出重试等诊断信息。
+//
+// 参数：
+//
+//	format: 格式字符串，不需要包含换行符
+//	args: 格式参数
+func (c *Client) Debugf(format string, args ...interface{}) {
+	if !c.Debug {
+		return
+	}
+	if c.logf != nil {
+		c.logf(format, args...)
+		return
+	}
+	fmt.Printf(format+"\n", args...)
+}
+
+// DoRaw 发送请求并返回响应中data字段的原始JSON
+//
+// 这是底层的扩展入口，用于调用SDK尚未封装的新接口或未公开的接口，
+// 复用与DoRequest相同的鉴权、请求头和重试逻辑，并检查BaseResponse的状态码。
+// 常规场景请优先使用各API模块中封装好的方法。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	method: HTTP方法，如"GET"、"POST"等
+//	path: API路径，如"/api/core/dataset/collection/read"
+//	body: 请求体数据，将被序列化为JSON格式，可以为nil
+//
+// 返回值：
+//
+//	[]byte: data字段的原始JSON，由调用者自行解析；响应不是标准格式时返回完整响应体
+//	error: 如果请求失败或状态码不是200，返回错误信息
+//
+// 使用示例：
+//
+//	raw, err := c.DoRaw(ctx, "POST", "/api/core/dataset/collection/read", map[string]string{"collectionId": id})
+//	var result struct{ Value string `json:"value"` }
+//	err = json.Unmarshal(raw, &result)
+func (c *Client) DoRaw(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	resp, err := c.DoRequestContext(ctx, method, path, body)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	var raw json.RawMessage
+	if err := c.ParseResponse(resp, &raw); err != nil {
+		return nil, err // 响应解析失败，返回错误
+	}
+
+	return raw, nil
+}
+
+// encodeBody 将请求体序列化为JSON
+//
+// 默认不转义HTML字符，避免<、>、&被编码为\u003c等形式导致请求体膨胀、难以调试；
+// 可通过WithEscapeHTML恢复json.Marshal的转义行为。
+func (c *Client) encodeBody(body interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(c.escapeHTML)
+	if err := encoder.Encode(body); err != nil {
+		return nil, err
+	}
+
+	// Encoder会在末尾追加换行符，这里去掉以保持与json.Marshal一致
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// baseURLFor 根据接口路径选择基础URL
+//
+// 商业版接口统一以/api/proApi/开头，设置了WithProApiBaseURL时发往单独的地址。
+func (c *Client) baseURLFor(path string) string {
+	if c.proApiBaseURL != "" && strings.HasPrefix(path, proApiPathPrefix) {
+		return c.proApiBaseURL
+	}
+	return c.BaseURL
+}
+
+// send 创建并发送单次HTTP请求
+//
+// 请求体以编码后的形式传入，便于在重试时重新构建请求。
+func (c *Client) send(ctx context.Context, method, path string, p payload, apiKey string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, path, p, apiKey)
 	if err != nil {
 		return nil, err // 请求创建失败，返回错误
 	}
 
-	// 设置请求头
-	req.Header.Set("Authorization", "Bearer "+c.APIKey) // 添加身份验证头
-	req.Header.Set("Content-Type", "application/json")  // 设置内容类型为JSON
-	req.Header.Set("User-Agent", "go-fastgpt-client")   // 设置用户代理
+	// 调用请求钩子，可用于注入追踪头、记录日志等
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
 
 	// 发送请求并返回响应
-	return c.HTTPClient.Do(req)
+	start := time.Now()
+	resp, err := c.httpClientFor(ctx).Do(req)
+	if err != nil || len(c.responseHooks) == 0 {
+		return resp, err
+	}
+
+	// 在响应体关闭时调用响应钩子，流式响应会在流结束后才触发
+	resp.Body = &hookedBody{
+		ReadCloser: resp.Body,
+		onClose: func() {
+			elapsed := time.Since(start)
+			for _, hook := range c.responseHooks {
+				hook(resp, elapsed)
+			}
+		},
+	}
+	return resp, nil
+}
+
+// newRequest 创建带有鉴权和默认请求头的HTTP请求
+func (c *Client) newRequest(ctx context.Context, method, path string, p payload, apiKey string) (*http.Request, error) {
+	reqBody, contentType, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建HTTP请求
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURLFor(path)+path, reqBody)
+	if err != nil {
+		if closer, ok := reqBody.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+
+	// 设置请求头
+	req.Header.Set("Authorization", "Bearer "+apiKey) // 添加身份验证头
+	req.Header.Set("Content-Type", contentType)       // 设置内容类型，默认为JSON
+	req.Header.Set("User-Agent", "go-fastgpt-client") // 设置用户代理
+
+	// 应用通过WithCallHeader设置的单次请求头
+	applyCallHeader(req)
+
+	return req, nil
+}
+
+// BuildRequest 创建与DoRequest相同的HTTP请求但不发送
+//
+// 这是用于自定义鉴权的扩展入口：调用方可以在请求上追加签名（如HMAC）等请求头，
+// 再通过Do使用客户端的Transport发送。请求包含Authorization、Content-Type、User-Agent
+// 和WithCallHeader设置的请求头，设置了WithKeyPool时从密钥池中取出密钥。
+// 只需要统一添加请求头时，使用WithRequestHook更简单。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消，也会传递WithCallHeader等单次请求选项
+//	method: HTTP方法，如"GET"、"POST"等
+//	path: API路径，如"/api/core/dataset/collection/read"
+//	body: 请求体数据，将被序列化为JSON格式，可以为nil
+//
+// 返回值：
+//
+//	*http.Request: 未发送的HTTP请求
+//	error: 如果请求体序列化失败、密钥池为空或请求创建失败，返回错误信息
+//
+// 注意事项：
+//   - 请求钩子（WithRequestHook）、响应钩子、401重试和密钥池的失效剔除只在DoRequest中生效，不会作用于该请求
+//
+// 使用示例：
+//
+//	req, err := c.BuildRequest(ctx, "POST", "/api/core/dataset/searchTest", searchReq)
+//	if err != nil {
+//	    return err
+//	}
+//	req.Header.Set("X-Signature", sign(req))
+//	resp, err := c.Do(req)
+//	if err != nil {
+//	    return err
+//	}
+//	var results []model.DatasetSearchTestResult
+//	err = c.ParseResponse(resp, &results)
+func (c *Client) BuildRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	p, err := c.encodePayload(body)
+	if err != nil {
+		return nil, wrapRequestError(method, path, err)
+	}
+
+	key := c.apiKey()
+	if c.keyPool != nil {
+		key, err = c.keyPool.Next()
+		if err != nil {
+			return nil, wrapRequestError(method, path, err)
+		}
+	}
+
+	req, err := c.newRequest(ctx, method, path, p, key)
+	if err != nil {
+		return nil, wrapRequestError(method, path, err)
+	}
+	return req, nil
+}
+
+// Do 使用客户端的Transport原样发送请求
+//
+// 通常与BuildRequest配合使用，不会修改请求，也不会调用钩子或重试。
+// 响应可以交给ParseResponse解析。
+//
+// 参数：
+//
+//	req: 待发送的HTTP请求
+//
+// 返回值：
+//
+//	*http.Response: HTTP响应对象，需要调用者处理响应体
+//	error: 如果请求发送失败，返回错误信息
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClientFor(req.Context()).Do(req)
+	if err != nil {
+		return nil, wrapRequestError(req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// hookedBody 在关闭时触发回调的响应体
+type hookedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	onClose func()
+}
+
+// Close 关闭响应体并触发回调，多次关闭只触发一次
+func (b *hookedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.onClose)
+	return err
 }
 
 // ParseResponse 解析HTTP响应体为指定的结构体
@@ -114,8 +576,11 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 // 注意事项：
 // - 该方法会自动关闭响应体
 // - 响应体必须是JSON格式
-// - v必须是结构体指针
-// - 该方法会检查BaseResponse的Code字段，200表示成功，其他状态码返回错误
+// - v必须是结构体指针，不需要返回数据时可以传nil
+// - 该方法会检查BaseResponse的Code字段，200表示成功，其他状态码返回*APIError
+// - 重定向和HTML页面（如SSO代理的登录页）会返回说明原因的*APIError，而不是JSON解析错误
+// - 返回的错误以"方法 路径: "开头，便于在日志中定位失败的接口，可通过errors.As获取*APIError
+// - 社区版部署调用商业版接口返回404时，错误同时包装ErrProFeatureRequired
 //
 // 优化说明：
 // 1. 对于标准BaseResponse格式：
@@ -127,6 +592,27 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 //
 // 3. 内存优化：使用io.ReadAll读取响应体，便于debug模式打印完整响应
 func (c *Client) ParseResponse(resp *http.Response, v interface{}) error {
+	err := c.parseResponse(resp, v)
+	if err != nil && resp.Request != nil {
+		// 读取完响应后请求的上下文可能已被取消，查询服务端信息时只保留其中的值
+		err = c.proFeatureError(context.WithoutCancel(resp.Request.Context()), resp.Request.URL.Path, err)
+		return wrapRequestError(resp.Request.Method, resp.Request.URL.Path, err)
+	}
+	return err
+}
+
+// wrapRequestError 在错误信息前加上请求方法和路径，路径中的查询参数会被去掉
+//
+// 使用%w包装，调用者仍可以通过errors.As获取*APIError。
+func wrapRequestError(method, path string, err error) error {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return fmt.Errorf("%s %s: %w", method, path, err)
+}
+
+// parseResponse 读取并解析响应体，检查BaseResponse的状态码
+func (c *Client) parseResponse(resp *http.Response, v interface{}) error {
 	defer resp.Body.Close() // 确保响应体被关闭
 
 	// 读取响应体内容
@@ -135,21 +621,37 @@ func (c *Client) ParseResponse(resp *http.Response, v interface{}) error {
 		return err
 	}
 
-	// 如果开启了debug模式，打印HTTP返回结果
-	if c.Debug {
-		fmt.Printf("HTTP Response: %s\n", string(body))
+	// 如果开启了debug模式，通过WithLogger设置的日志函数输出HTTP返回结果
+	c.Debugf("HTTP Response: %s", body)
+
+	// 重定向或HTML页面通常表示被SSO代理拦截，直接返回说明原因的错误
+	if apiErr := nonJSONError(resp, body); apiErr != nil {
+		return apiErr
 	}
 
 	// 首先解析为BaseResponse，检查状态码
 	var baseResp model.BaseResponse
 	if err := json.Unmarshal(body, &baseResp); err != nil {
 		// 如果不是BaseResponse格式，直接解析为目标结构体
+		if v == nil {
+			return nil
+		}
 		return json.Unmarshal(body, v)
 	}
 
-	// 检查状态码，200表示成功，其他状态码返回错误
+	// 检查状态码，200表示成功，其他状态码返回结构化错误
 	if baseResp.Code != 200 {
-		return fmt.Errorf("API error: %s (code: %d)", baseResp.Message, baseResp.Code)
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       baseResp.Code,
+			StatusText: baseResp.StatusText,
+			Message:    baseResp.Message,
+		}
+	}
+
+	// 调用者不需要返回数据，或者响应中没有data字段时直接返回
+	if v == nil || len(baseResp.Data) == 0 {
+		return nil
 	}
 
 	// 如果状态码是200，直接将Data字段解析为目标结构体
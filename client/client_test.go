@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDebugResponseUsesLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"code":200,"statusText":"","message":"","data":"ok"}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		debug bool
+		want  int
+	}{
+		{name: "开启Debug时输出到日志函数", debug: true, want: 1},
+		{name: "未开启Debug时不输出", debug: false, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			c := NewClient(server.URL, "key", WithLogger(func(format string, args ...interface{}) {
+				lines = append(lines, fmt.Sprintf(format, args...))
+			}))
+			c.Debug = tt.debug
+
+			resp, err := c.DoRequest("GET", "/api/test", nil)
+			if err != nil {
+				t.Fatalf("DoRequest() error = %v", err)
+			}
+			var data string
+			if err := c.ParseResponse(resp, &data); err != nil {
+				t.Fatalf("ParseResponse() error = %v", err)
+			}
+
+			if len(lines) != tt.want {
+				t.Fatalf("logged %d lines, want %d: %q", len(lines), tt.want, lines)
+			}
+			if tt.want > 0 && !strings.HasPrefix(lines[0], `HTTP Response: {"code":200`) {
+				t.Errorf("log line = %q", lines[0])
+			}
+		})
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/api/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/api/protected", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/sso/login", http.StatusFound)
+	})
+	mux.HandleFunc("/api/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"code":200,"statusText":"","message":"","data":"ok"}`)
+	})
+	mux.HandleFunc("/sso/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<!DOCTYPE html><html>login</html>")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		opts           []Option
+		path           string
+		wantStatusCode int // 为0时期望成功
+		wantCode       int
+	}{
+		{name: "默认返回重定向", path: "/api/moved", wantStatusCode: http.StatusFound, wantCode: http.StatusFound},
+		{name: "默认识别登录页", path: "/api/protected", wantStatusCode: http.StatusFound, wantCode: http.StatusUnauthorized},
+		{name: "开启跟随时跟随重定向", opts: []Option{WithFollowRedirects()}, path: "/api/moved"},
+		{name: "开启跟随时识别跟随到的登录页", opts: []Option{WithFollowRedirects()}, path: "/api/protected", wantStatusCode: http.StatusOK, wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range []*Client{NewClient(server.URL, "key", tt.opts...), NewClient(server.URL, "key").Clone(tt.opts...)} {
+				resp, err := c.DoRequest("GET", tt.path, nil)
+				if err != nil {
+					t.Fatalf("DoRequest() error = %v", err)
+				}
+				var data string
+				err = c.ParseResponse(resp, &data)
+
+				if tt.wantStatusCode == 0 {
+					if err != nil || data != "ok" {
+						t.Errorf("ParseResponse() = %q, %v, want ok", data, err)
+					}
+					continue
+				}
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("ParseResponse() error = %v, want *APIError", err)
+				}
+				if apiErr.StatusCode != tt.wantStatusCode || apiErr.Code != tt.wantCode {
+					t.Errorf("APIError = %d/%d, want %d/%d: %s", apiErr.StatusCode, apiErr.Code, tt.wantStatusCode, tt.wantCode, apiErr.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	const n = 100
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer key-") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":400,"statusText":"","message":"bad key","data":null}`)
+			return
+		}
+		fmt.Fprint(w, `{"code":200,"statusText":"","message":"","data":"ok"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "key-0")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			c.SetAPIKey(fmt.Sprintf("key-%d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			resp, err := c.DoRequestContext(context.Background(), "POST", "/api/test", map[string]string{"q": "问题"})
+			if err != nil {
+				errs <- err
+				return
+			}
+			var data string
+			if err := c.ParseResponse(resp, &data); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("request error = %v", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 代理收到的是完整的目标地址
+		proxiedHost = r.URL.Host
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"code":200,"statusText":"","message":"","data":"ok"}`)
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name     string
+		proxyURL string
+		wantErr  bool
+	}{
+		{name: "通过代理发送", proxyURL: proxy.URL},
+		{name: "代理地址无效时返回错误", proxyURL: "://bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxiedHost = ""
+			c := NewClient("http://fastgpt.example.com", "key", WithProxy(tt.proxyURL))
+			resp, err := c.DoRequest("GET", "/api/test", nil)
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("DoRequest() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DoRequest() error = %v", err)
+			}
+			var data string
+			if err := c.ParseResponse(resp, &data); err != nil {
+				t.Fatalf("ParseResponse() error = %v", err)
+			}
+			if proxiedHost != "fastgpt.example.com" {
+				t.Errorf("proxy received host %q, want %q", proxiedHost, "fastgpt.example.com")
+			}
+		})
+	}
+}
+
+func TestDefaultTransportUsesEnvironmentProxy(t *testing.T) {
+	c := NewClient("http://fastgpt.example.com", "key")
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", c.HTTPClient.Transport)
+	}
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	if transport == defaultTransport {
+		t.Fatal("Transport should be a clone of http.DefaultTransport")
+	}
+	if reflect.ValueOf(transport.Proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+		t.Error("Transport.Proxy should fall back to http.ProxyFromEnvironment")
+	}
+	if transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost)
+	}
+}
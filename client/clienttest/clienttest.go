@@ -0,0 +1,252 @@
+// Package clienttest 提供用于单元测试的FastGPT客户端替身
+//
+// Fake实现了client.Doer接口，可以直接传给NewAppAPI、NewChatAPI、NewDatasetAPI，
+// 按"方法+路径"返回预先设置的响应，并记录所有收到的请求，
+// 使调用方无需启动httptest服务即可测试与FastGPT的集成逻辑。
+//
+// 使用示例：
+//
+//	fake := clienttest.NewFake()
+//	fake.OnData("POST", "/api/core/dataset/create", "new-dataset-id")
+//
+//	datasetAPI := dataset.NewDatasetAPI(fake)
+//	id, err := datasetAPI.CreateDataset(&model.DatasetCreateRequest{Name: "test"})
+//
+//	calls := fake.Calls() // 检查发送的请求
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/xxjwxc/fastgpt/client"
+)
+
+// Call 记录的单次请求
+type Call struct {
+	Method string // HTTP方法
+	Path   string // 请求路径，包含查询参数
+	Body   []byte // JSON序列化后的请求体（不转义HTML字符），无请求体时为nil；*client.RawBody为读取到的原始内容
+
+	ContentType string      // 请求体的Content-Type，JSON请求体为"application/json"，无请求体时为空
+	Header      http.Header // 通过client.WithCallHeader设置的单次请求头，未设置时为nil
+}
+
+// Response 预设的响应
+type Response struct {
+	StatusCode int    // HTTP状态码，为0时按200处理
+	Body       string // 响应体，可以是JSON或SSE流
+}
+
+// Fake 可记录、可回放的客户端替身，实现client.Doer接口
+//
+// Fake可以在多个goroutine中并发使用。
+type Fake struct {
+	mu        sync.Mutex
+	responses map[string][]Response // 按"方法 路径"排队的响应
+	calls     []Call                // 已收到的请求
+	parser    *client.Client        // 复用真实客户端的响应解析逻辑
+}
+
+// 确保Fake实现了client.Doer接口
+var _ client.Doer = (*Fake)(nil)
+
+// NewFake 创建客户端替身
+func NewFake() *Fake {
+	return &Fake{
+		responses: make(map[string][]Response),
+		parser:    client.NewClient("", ""),
+	}
+}
+
+// On 为指定请求设置响应
+//
+// 同一请求可多次调用On，响应按设置顺序依次返回，最后一个响应会被重复使用。
+// path可以包含查询参数进行精确匹配，也可以只写路径部分匹配任意查询参数。
+//
+// 参数：
+//
+//	method: HTTP方法，如"POST"
+//	path: 请求路径，如"/api/core/dataset/create"
+//	statusCode: HTTP状态码
+//	body: 响应体
+//
+// 返回值：
+//
+//	*Fake: 当前实例，便于链式调用
+func (f *Fake) On(method, path string, statusCode int, body string) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := method + " " + path
+	f.responses[key] = append(f.responses[key], Response{StatusCode: statusCode, Body: body})
+	return f
+}
+
+// OnData 为指定请求设置成功响应，data会被包装为FastGPT标准的BaseResponse格式
+//
+// 参数：
+//
+//	method: HTTP方法
+//	path: 请求路径
+//	data: 响应中的data字段，会被序列化为JSON
+//
+// 返回值：
+//
+//	*Fake: 当前实例，便于链式调用
+func (f *Fake) OnData(method, path string, data interface{}) *Fake {
+	body, err := json.Marshal(map[string]interface{}{
+		"code":       200,
+		"statusText": "",
+		"message":    "",
+		"data":       data,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("clienttest: 序列化响应数据失败: %v", err))
+	}
+	return f.On(method, path, http.StatusOK, string(body))
+}
+
+// OnError 为指定请求设置错误响应
+//
+// 参数：
+//
+//	method: HTTP方法
+//	path: 请求路径
+//	code: 业务状态码，同时作为HTTP状态码
+//	statusText: 状态文本，如"unExistCollection"
+//	message: 错误信息
+//
+// 返回值：
+//
+//	*Fake: 当前实例，便于链式调用
+func (f *Fake) OnError(method, path string, code int, statusText, message string) *Fake {
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":       code,
+		"statusText": statusText,
+		"message":    message,
+	})
+	return f.On(method, path, code, string(body))
+}
+
+// Calls 返回已收到的所有请求
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calls := make([]Call, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// Reset 清空已设置的响应和已记录的请求
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses = make(map[string][]Response)
+	f.calls = nil
+}
+
+// DoRequest 记录请求并返回预设的响应，实现client.Doer接口
+//
+// 未设置响应的请求会返回错误。
+func (f *Fake) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+	return f.DoRequestContext(context.Background(), method, path, body)
+}
+
+// DoRequestContext 记录请求并返回预设的响应，实现client.Doer接口
+//
+// ctx已取消时直接返回ctx的错误。
+func (f *Fake) DoRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	call := Call{Method: method, Path: path, Header: client.CallHeader(ctx)}
+	if raw, ok := body.(*client.RawBody); ok && raw != nil {
+		// 与真实客户端一样读取完整的请求体，上传进度等回调会被触发
+		data, err := readRawBody(raw)
+		if err != nil {
+			return nil, err
+		}
+		call.Body = data
+		call.ContentType = raw.ContentType
+	} else if body != nil {
+		// 与真实客户端的默认编码保持一致，不转义HTML字符
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(body); err != nil {
+			return nil, err
+		}
+		call.Body = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		call.ContentType = "application/json"
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, call)
+
+	resp, ok := f.next(method + " " + path)
+	if !ok {
+		// 忽略查询参数再匹配一次
+		resp, ok = f.next(method + " " + strings.SplitN(path, "?", 2)[0])
+	}
+	if !ok {
+		return nil, fmt.Errorf("clienttest: 未设置请求 %s %s 的响应", method, path)
+	}
+
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	// 与真实响应一样携带请求信息，ParseResponse据此在错误中加上方法和路径
+	req, err := http.NewRequestWithContext(ctx, method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(resp.Body)),
+		Request:    req,
+	}, nil
+}
+
+// ParseResponse 使用真实客户端的逻辑解析响应，实现client.Doer接口
+func (f *Fake) ParseResponse(resp *http.Response, v interface{}) error {
+	return f.parser.ParseResponse(resp, v)
+}
+
+// next 取出指定键的下一个响应，最后一个响应会被保留重复使用
+func (f *Fake) next(key string) (Response, bool) {
+	queue := f.responses[key]
+	if len(queue) == 0 {
+		return Response{}, false
+	}
+	if len(queue) > 1 {
+		f.responses[key] = queue[1:]
+	}
+	return queue[0], true
+}
+
+// readRawBody 读取*client.RawBody的完整内容
+func readRawBody(raw *client.RawBody) ([]byte, error) {
+	r, err := raw.Open()
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+	return io.ReadAll(r)
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// APIError FastGPT接口返回的结构化错误
+//
+// 当HTTP状态码或响应体中的业务状态码表示失败时返回该错误，
+// 调用者可以通过errors.As获取具体的状态码进行区分处理。
+//
+// 使用示例：
+//
+//	var apiErr *client.APIError
+//	if errors.As(err, &apiErr) && apiErr.IsUnauthorized() {
+//	    // 处理鉴权失败
+//	}
+type APIError struct {
+	StatusCode int    // HTTP状态码
+	Code       int    // 响应体中的业务状态码
+	StatusText string // 响应体中的状态文本，FastGPT通常在此返回错误枚举，如unExistCollection
+	Message    string // 错误信息
+}
+
+// Error 实现error接口
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %s (code: %d)", e.Message, e.Code)
+}
+
+// IsUnauthorized 判断是否为鉴权失败（401未认证或403无权限）
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden ||
+		e.Code == http.StatusUnauthorized || e.Code == http.StatusForbidden
+}
+
+// IsNotFound 判断是否为资源不存在
+//
+// FastGPT对不存在的资源通常不返回404，而是返回500并在statusText或message中
+// 给出unExistDataset、unExistCollection等错误枚举，因此这里同时检查状态码和错误文本。
+func (e *APIError) IsNotFound() bool {
+	if e.StatusCode == http.StatusNotFound || e.Code == http.StatusNotFound {
+		return true
+	}
+
+	text := strings.ToLower(e.StatusText + " " + e.Message)
+	for _, keyword := range notFoundKeywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// 可通过errors.Is判断的错误类别，匹配规则见errorClasses
+var (
+	// ErrInsufficientPoints 团队AI积分不足，继续重试没有意义，应暂停任务并提醒充值
+	ErrInsufficientPoints = errors.New("AI积分不足")
+	// ErrQuotaExceeded 团队套餐额度不足，如知识库容量、知识库数量、应用数量等
+	ErrQuotaExceeded = errors.New("套餐额度不足")
+	// ErrRateLimited 请求过于频繁，稍后重试即可
+	ErrRateLimited = errors.New("请求过于频繁")
+)
+
+// errorClass APIError到错误类别的匹配规则
+type errorClass struct {
+	sentinel error                // 错误类别
+	match    func(*APIError) bool // 判断APIError是否属于该类别
+}
+
+// errorClasses 已注册的错误类别，按注册顺序匹配
+//
+// 默认规则：
+// - ErrRateLimited：HTTP状态码或业务状态码为429，或错误文本包含tooManyRequest、rate limit
+// - ErrInsufficientPoints：错误文本包含aiPointsNotEnough（或中文"积分不足"）
+// - ErrQuotaExceeded：错误文本包含其他NotEnough枚举，如datasetSizeNotEnough、appAmountNotEnough
+var (
+	errorClassesMu sync.RWMutex
+	errorClasses   = []errorClass{
+		{ErrRateLimited, func(e *APIError) bool {
+			return e.StatusCode == http.StatusTooManyRequests || e.Code == http.StatusTooManyRequests ||
+				e.containsAny("toomanyrequest", "rate limit", "ratelimit")
+		}},
+		{ErrInsufficientPoints, func(e *APIError) bool {
+			return e.containsAny("aipointsnotenough", "积分不足")
+		}},
+		{ErrQuotaExceeded, func(e *APIError) bool {
+			return !e.containsAny("aipointsnotenough") && e.containsAny("notenough")
+		}},
+	}
+)
+
+// RegisterErrorClass 注册自定义错误类别
+//
+// 注册后，满足match的APIError可以通过errors.Is(err, sentinel)判断。
+// 可用于适配私有部署返回的自定义错误码，也可以为已有类别（如ErrRateLimited）追加匹配规则。
+//
+// 参数：
+//
+//	sentinel: 错误类别，通常为包级别的errors.New变量
+//	match: 判断APIError是否属于该类别
+//
+// 使用示例：
+//
+//	client.RegisterErrorClass(client.ErrRateLimited, func(e *client.APIError) bool {
+//	    return e.Code == 503001 // 私有网关的限流错误码
+//	})
+func RegisterErrorClass(sentinel error, match func(*APIError) bool) {
+	errorClassesMu.Lock()
+	defer errorClassesMu.Unlock()
+
+	errorClasses = append(errorClasses, errorClass{sentinel: sentinel, match: match})
+}
+
+// Is 判断APIError是否属于target表示的错误类别，供errors.Is使用
+//
+// 使用示例：
+//
+//	if errors.Is(err, client.ErrInsufficientPoints) {
+//	    // 暂停任务并提醒充值
+//	} else if errors.Is(err, client.ErrRateLimited) {
+//	    // 稍后重试
+//	}
+func (e *APIError) Is(target error) bool {
+	errorClassesMu.RLock()
+	defer errorClassesMu.RUnlock()
+
+	for _, class := range errorClasses {
+		if class.sentinel == target && class.match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny 判断状态文本或错误信息是否包含任一关键字（关键字需为小写）
+func (e *APIError) containsAny(keywords ...string) bool {
+	text := strings.ToLower(e.StatusText + " " + e.Message)
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// loginPathKeywords 重定向地址中表示登录页的关键字（小写）
+var loginPathKeywords = []string{"login", "signin", "sso", "auth"}
+
+// nonJSONError 识别重定向和HTML页面等非JSON响应，返回说明真实原因的APIError
+//
+// 部署在SSO代理后的FastGPT在密钥错误时会返回302跳转或HTML登录页，而不是JSON错误，
+// 直接按JSON解析只会得到难以理解的解析错误。无法识别时返回nil。
+func nonJSONError(resp *http.Response, body []byte) *APIError {
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		location := resp.Header.Get("Location")
+		if isLoginURL(location) {
+			return &APIError{
+				StatusCode: resp.StatusCode,
+				Code:       http.StatusUnauthorized,
+				Message:    fmt.Sprintf("鉴权失败（被重定向到登录页 %s）", location),
+			}
+		}
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.StatusCode,
+			Message:    fmt.Sprintf("请求被重定向到 %s，请检查BaseURL是否正确", location),
+		}
+	}
+
+	if !isHTML(resp, body) {
+		return nil
+	}
+	// 跟随重定向后收到HTML页面时，说明最终到达的地址
+	if final, ok := redirectedURL(resp); ok {
+		if isLoginURL(final) {
+			return &APIError{
+				StatusCode: resp.StatusCode,
+				Code:       http.StatusUnauthorized,
+				Message:    fmt.Sprintf("鉴权失败（被重定向到登录页 %s）", final),
+			}
+		}
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.StatusCode,
+			Message:    fmt.Sprintf("请求被重定向到 %s 并收到HTML页面，请检查BaseURL是否正确", final),
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.StatusCode,
+			Message:    "鉴权失败（收到HTML登录页）",
+		}
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       resp.StatusCode,
+		Message:    fmt.Sprintf("收到HTML页面而不是JSON响应（HTTP %d），请检查BaseURL和代理配置", resp.StatusCode),
+	}
+}
+
+// isLoginURL 判断地址是否像登录页
+func isLoginURL(location string) bool {
+	lower := strings.ToLower(location)
+	for _, keyword := range loginPathKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectedURL 返回跟随重定向后最终请求的地址，响应不是经重定向得到时返回false
+func redirectedURL(resp *http.Response) (string, bool) {
+	if resp.Request == nil || resp.Request.Response == nil || resp.Request.URL == nil {
+		return "", false
+	}
+	return resp.Request.URL.String(), true
+}
+
+// isHTML 根据Content-Type或响应体开头判断是否为HTML页面
+func isHTML(resp *http.Response, body []byte) bool {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/html") {
+		return true
+	}
+	trimmed := strings.ToLower(strings.TrimSpace(string(body[:min(len(body), 64)])))
+	return strings.HasPrefix(trimmed, "<!doctype html") || strings.HasPrefix(trimmed, "<html")
+}
+
+// notFoundKeywords 表示资源不存在的错误文本关键字（小写）
+var notFoundKeywords = []string{"unexist", "notfound", "not found", "not exist"}
+
+// IsNotFound 判断错误链中是否包含资源不存在的APIError
+//
+// 参数：
+//
+//	err: 任意接口调用返回的错误
+//
+// 返回值：
+//
+//	bool: 是否为资源不存在
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
+
+// IsUnauthorized 判断错误链中是否包含鉴权失败的APIError
+//
+// 参数：
+//
+//	err: 任意接口调用返回的错误
+//
+// 返回值：
+//
+//	bool: 是否为401或403鉴权失败
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsUnauthorized()
+}
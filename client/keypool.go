@@ -0,0 +1,109 @@
+package client
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoAvailableKey 密钥池中没有可用的API密钥
+var ErrNoAvailableKey = errors.New("密钥池中没有可用的API密钥")
+
+// KeyPool API密钥池
+//
+// 按轮询方式为每次请求分配API密钥，用于将批量请求分摊到多个应用密钥上以提高吞吐量。
+// 返回401的密钥会被自动移出密钥池。KeyPool可以在多个goroutine之间共享使用。
+//
+// 注意事项：
+//   - FastGPT的限流按密钥（及其所属团队）计算，同一团队下的多个密钥可能共享团队级别的限额，
+//     增加密钥并不一定能线性提升吞吐量
+//   - 被移除的密钥不会自动恢复，如需恢复请调用Add
+type KeyPool struct {
+	mu   sync.Mutex
+	keys []string // 可用的API密钥
+	next int      // 下一次分配的密钥下标
+}
+
+// NewKeyPool 创建API密钥池
+//
+// 参数：
+//
+//	keys: API密钥列表，空字符串和重复的密钥会被忽略
+//
+// 返回值：
+//
+//	*KeyPool: 密钥池实例
+//
+// 使用示例：
+//
+//	pool := client.NewKeyPool("sk-key1", "sk-key2", "sk-key3")
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "", client.WithKeyPool(pool))
+func NewKeyPool(keys ...string) *KeyPool {
+	p := &KeyPool{}
+	p.Add(keys...)
+	return p
+}
+
+// Add 向密钥池添加API密钥，空字符串和已存在的密钥会被忽略
+func (p *KeyPool) Add(keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range keys {
+		if key != "" && p.indexOf(key) < 0 {
+			p.keys = append(p.keys, key)
+		}
+	}
+}
+
+// Remove 从密钥池移除API密钥
+func (p *KeyPool) Remove(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i := p.indexOf(key)
+	if i < 0 {
+		return
+	}
+	p.keys = append(p.keys[:i], p.keys[i+1:]...)
+	if i < p.next {
+		p.next-- // 保持轮询顺序不变
+	}
+}
+
+// Next 按轮询顺序返回下一个API密钥
+//
+// 返回值：
+//
+//	string: API密钥
+//	error: 密钥池为空时返回ErrNoAvailableKey
+func (p *KeyPool) Next() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", ErrNoAvailableKey
+	}
+	if p.next >= len(p.keys) {
+		p.next = 0
+	}
+	key := p.keys[p.next]
+	p.next++
+	return key, nil
+}
+
+// Len 返回密钥池中可用的密钥数量
+func (p *KeyPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// indexOf 返回密钥的下标，不存在时返回-1，调用者需持有锁
+func (p *KeyPool) indexOf(key string) int {
+	for i, k := range p.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,403 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 连接池默认配置
+const (
+	defaultMaxIdleConns        = 100 // 所有主机的最大空闲连接数
+	defaultMaxIdleConnsPerHost = 32  // 每个主机的最大空闲连接数
+)
+
+// Option 客户端配置选项
+//
+// 通过函数式选项模式配置Client，在NewClient或fastgpt.NewFastGPT时传入。
+//
+// 使用示例：
+//
+//	c := client.NewClient("https://localhost:3000", "sk-xxx", client.WithRootCAs(pool))
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的HTTP客户端
+//
+// 参数：
+//
+//	httpClient: 自定义的HTTP客户端，为nil时忽略
+//
+// 注意事项：
+// - 设置了自定义HTTP客户端后，WithInsecureSkipVerify和WithRootCAs等传输层选项将被忽略，
+// 需要在自定义客户端的Transport中自行配置TLS
+// - 默认不跟随重定向的策略和WithFollowRedirects对自定义客户端无效，重定向行为由自定义客户端的CheckRedirect决定
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+			c.customHTTPClient = true
+		}
+	}
+}
+
+// WithInsecureSkipVerify 跳过TLS证书校验
+//
+// 用于连接使用自签名证书的本地或开发环境FastGPT实例。
+//
+// 警告：该选项会关闭证书校验，使连接容易受到中间人攻击，仅限开发调试使用，
+// 切勿在生产环境开启。生产环境请使用WithRootCAs信任自签名证书。
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://localhost:3000", "sk-xxx", client.WithInsecureSkipVerify())
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) {
+		c.tlsConfig().InsecureSkipVerify = true
+	}
+}
+
+// WithRootCAs 使用指定的根证书池校验服务端证书
+//
+// 参数：
+//
+//	pool: 根证书池，通常包含自签名证书或企业内部CA证书
+//
+// 使用示例：
+//
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(caPEM)
+//	fgpt := fastgpt.NewFastGPT("https://fastgpt.internal", "sk-xxx", client.WithRootCAs(pool))
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		c.tlsConfig().RootCAs = pool
+	}
+}
+
+// WithAPIKey 设置API密钥
+//
+// 主要用于Clone时为副本单独指定密钥，NewClient时直接传入apiKey即可。
+//
+// 参数：
+//
+//	apiKey: API密钥
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.APIKey = apiKey
+	}
+}
+
+// WithBaseURL 设置FastGPT服务基础URL
+//
+// 主要用于Clone时为副本单独指定服务地址，NewClient时直接传入baseURL即可。
+//
+// 参数：
+//
+//	baseURL: FastGPT服务基础URL，例如：https://cloud.fastgpt.cn
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithUnauthorizedHandler 设置鉴权失败时的密钥刷新函数
+//
+// 当请求返回HTTP 401时，调用handler获取新的API密钥，更新客户端的APIKey后重试一次。
+// 每个请求最多只重试一次，重试后仍返回401则直接交给调用者处理，避免无限重试。
+//
+// 参数：
+//
+//	handler: 密钥刷新函数，返回新的API密钥；返回错误时不再重试
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT(baseURL, key, client.WithUnauthorizedHandler(func() (string, error) {
+//	    return secrets.Get("fastgpt-api-key")
+//	}))
+func WithUnauthorizedHandler(handler func() (newKey string, err error)) Option {
+	return func(c *Client) {
+		c.unauthorizedHandler = handler
+	}
+}
+
+// WithKeyPool 使用API密钥池为每次请求轮询分配密钥
+//
+// 设置后NewClient传入的apiKey和APIKey字段将被忽略。返回401的密钥会被移出密钥池，
+// 并使用下一个密钥重试一次；密钥池为空时请求返回ErrNoAvailableKey。
+//
+// 参数：
+//
+//	pool: API密钥池，为nil时忽略
+//
+// 注意事项：
+// - 设置了密钥池时，WithUnauthorizedHandler不再生效
+//
+// 使用示例：
+//
+//	pool := client.NewKeyPool("sk-key1", "sk-key2")
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "", client.WithKeyPool(pool))
+func WithKeyPool(pool *KeyPool) Option {
+	return func(c *Client) {
+		c.keyPool = pool
+	}
+}
+
+// WithProxy 通过指定的HTTP代理发送请求
+//
+// 未设置该选项时，默认读取环境变量HTTP_PROXY、HTTPS_PROXY和NO_PROXY中的代理配置。
+//
+// 参数：
+//
+//	proxyURL: 代理地址，例如：http://proxy.example.com:8080；地址无效时请求会返回解析错误
+//
+// 注意事项：
+// - 与WithHTTPClient同时使用时，该选项将被忽略
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx", client.WithProxy("http://127.0.0.1:7890"))
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) {
+		c.transportChanged = true
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			// 地址无效时不静默回退为直连，而是在发送请求时返回错误
+			c.proxy = func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("无效的代理地址 %q: %w", proxyURL, err)
+			}
+			return
+		}
+		c.proxy = http.ProxyURL(u)
+	}
+}
+
+// poolConfig 通过WithTransportConfig设置的连接池参数，0表示使用默认值
+type poolConfig struct {
+	maxIdleConns        int           // 所有主机的最大空闲连接数
+	maxIdleConnsPerHost int           // 每个主机的最大空闲连接数
+	idleTimeout         time.Duration // 空闲连接的保活时间
+}
+
+// WithTransportConfig 设置连接池参数
+//
+// 默认每个主机保留32个空闲连接，所有主机共100个，空闲连接保持90秒。
+// 大量goroutine并发请求同一FastGPT实例（如PushDataInBatches开启并发）时，
+// 可以调大空闲连接数，避免连接被频繁关闭和重新建立。
+//
+// 参数：
+//
+//	maxIdleConns: 所有主机的最大空闲连接数，0表示使用默认值
+//	maxIdleConnsPerHost: 每个主机的最大空闲连接数，0表示使用默认值
+//	idleTimeout: 空闲连接的保活时间，0表示使用默认值
+//
+// 注意事项：
+// - 与WithHTTPClient同时使用时，该选项将被忽略，请在自定义客户端的Transport中自行配置
+// - maxIdleConnsPerHost大于maxIdleConns时，实际生效的空闲连接数受maxIdleConns限制
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx",
+//	    client.WithTransportConfig(200, 64, 2*time.Minute))
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) Option {
+	return func(c *Client) {
+		c.transportChanged = true
+		c.pool = &poolConfig{
+			maxIdleConns:        maxIdleConns,
+			maxIdleConnsPerHost: maxIdleConnsPerHost,
+			idleTimeout:         idleTimeout,
+		}
+	}
+}
+
+// WithEscapeHTML 序列化请求体时转义HTML字符
+//
+// 默认情况下请求体中的<、>、&按原样发送；开启该选项后恢复json.Marshal的行为，
+// 将其转义为\u003c、\u003e、\u0026，仅在依赖旧行为时使用。
+func WithEscapeHTML() Option {
+	return func(c *Client) {
+		c.escapeHTML = true
+	}
+}
+
+// WithProApiBaseURL 为商业版接口单独设置基础URL
+//
+// 部分私有化部署中，商业版接口（路径以/api/proApi/开头，如应用日志看板、外部文件集合）
+// 与核心接口部署在不同的地址。设置后这些接口发往proBaseURL，对话、知识库等核心接口仍使用BaseURL。
+// 未设置时所有接口都使用BaseURL，云服务用户无需关心。
+//
+// 参数：
+//
+//	proBaseURL: 商业版接口的基础URL，例如：https://pro.fastgpt.internal
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://fastgpt.internal", "sk-xxx",
+//	    client.WithProApiBaseURL("https://pro.fastgpt.internal"))
+func WithProApiBaseURL(proBaseURL string) Option {
+	return func(c *Client) {
+		c.proApiBaseURL = proBaseURL
+	}
+}
+
+// WithRequestHook 添加请求钩子
+//
+// 钩子在每次发送HTTP请求前调用（包括鉴权失败后的重试），可用于注入追踪头、记录日志等。
+// 多次调用时按添加顺序依次执行，传入nil时忽略。
+//
+// 参数：
+//
+//	hook: 请求钩子，可以修改请求头
+//
+// 使用示例：
+//
+//	client.WithRequestHook(func(req *http.Request) {
+//	    otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+//	})
+func WithRequestHook(hook func(*http.Request)) Option {
+	return func(c *Client) {
+		if hook != nil {
+			c.requestHooks = append(c.requestHooks, hook)
+		}
+	}
+}
+
+// WithResponseHook 添加响应钩子
+//
+// 钩子在响应体关闭时调用，elapsed为从发送请求到响应体关闭的耗时；
+// 对于对话等流式请求，钩子会在整个流读取完成后才触发。
+// 请求未能发出（如网络错误）时不会调用。多次调用时按添加顺序依次执行，传入nil时忽略。
+//
+// 参数：
+//
+//	hook: 响应钩子，不应读取响应体
+//
+// 使用示例：
+//
+//	client.WithResponseHook(func(resp *http.Response, elapsed time.Duration) {
+//	    latency.WithLabelValues(resp.Request.URL.Path).Observe(elapsed.Seconds())
+//	})
+func WithResponseHook(hook func(*http.Response, time.Duration)) Option {
+	return func(c *Client) {
+		if hook != nil {
+			c.responseHooks = append(c.responseHooks, hook)
+		}
+	}
+}
+
+// WithLogger 设置debug日志的输出函数
+//
+// 开启Debug后，SDK会输出每个响应的响应体（以"HTTP Response: "开头），
+// 并在重试请求时输出日志，说明请求变慢的原因，格式固定便于解析：
+//
+//	fastgpt: retry 1/1 after 401, refreshing api key
+//	fastgpt: retry 1/1 after 401, switching api key from pool
+//	fastgpt: retry 1/2 after connection reset: <原因>
+//	fastgpt: reconnect 1/3 after stream interrupted: <原因>
+//
+// 未开启Debug时不输出任何日志。未设置时使用fmt.Printf输出到标准输出。
+//
+// 参数：
+//
+//	logf: 日志输出函数，每次调用输出一行，不包含换行符；传入nil时忽略
+//
+// 使用示例：
+//
+//	c := client.NewClient(baseURL, apiKey, client.WithLogger(log.Printf))
+//	c.Debug = true
+func WithLogger(logf func(format string, args ...interface{})) Option {
+	return func(c *Client) {
+		if logf != nil {
+			c.logf = logf
+		}
+	}
+}
+
+// WithFollowRedirects 跟随重定向
+//
+// 默认客户端不跟随重定向：3xx响应直接返回包含Location的*APIError，重定向到登录页（如SSO代理）时Code为401，
+// 避免请求连同API密钥被转发到其他地址，并在网关配置错误时尽早失败。
+// 开启该选项后与net/http一样跟随重定向，适用于BaseURL会被重定向到新地址（如HTTP跳转HTTPS）的部署；
+// 跟随后收到的是HTML页面时，ParseResponse仍会返回说明被重定向到何处的*APIError。
+//
+// 注意事项：
+// - 使用WithHTTPClient设置了自定义HTTP客户端时该选项被忽略，重定向行为由自定义客户端的CheckRedirect决定
+//
+// 使用示例：
+//
+//	c := client.NewClient("http://fastgpt.internal", "sk-xxx", client.WithFollowRedirects())
+func WithFollowRedirects() Option {
+	return func(c *Client) {
+		c.followRedirects = true
+	}
+}
+
+// applyRedirectPolicy 设置默认HTTP客户端的重定向策略，开启WithFollowRedirects时跟随重定向
+func (c *Client) applyRedirectPolicy() {
+	if c.customHTTPClient {
+		return
+	}
+	if c.followRedirects {
+		c.HTTPClient.CheckRedirect = nil
+		return
+	}
+	c.HTTPClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+}
+
+// tlsConfig 返回待应用的TLS配置，不存在时创建
+func (c *Client) tlsConfig() *tls.Config {
+	if c.tls == nil {
+		c.tls = &tls.Config{}
+	}
+	c.transportChanged = true
+	return c.tls
+}
+
+// applyTransport 根据选项构建底层Transport
+//
+// 在所有选项应用完成后调用，保证选项的传入顺序不影响最终结果。
+// 如果使用了WithHTTPClient，则保留自定义客户端的Transport不做修改。
+func (c *Client) applyTransport() {
+	if c.customHTTPClient {
+		return
+	}
+
+	transport := newTransport()
+	if c.pool != nil {
+		if c.pool.maxIdleConns > 0 {
+			transport.MaxIdleConns = c.pool.maxIdleConns
+		}
+		if c.pool.maxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = c.pool.maxIdleConnsPerHost
+		}
+		if c.pool.idleTimeout > 0 {
+			transport.IdleConnTimeout = c.pool.idleTimeout
+		}
+	}
+	if c.tls != nil {
+		transport.TLSClientConfig = c.tls
+	}
+	if c.proxy != nil {
+		transport.Proxy = c.proxy
+	}
+	c.HTTPClient.Transport = transport
+}
+
+// newTransport 创建新的Transport
+//
+// 优先基于默认Transport克隆，保留超时等默认配置，并默认从环境变量读取代理配置。
+// 默认Transport每个主机只保留2个空闲连接，并发请求较多时会频繁新建连接，这里适当调大。
+func newTransport() *http.Transport {
+	var transport *http.Transport
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = &http.Transport{Proxy: http.ProxyFromEnvironment}
+	}
+	transport.MaxIdleConns = defaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	return transport
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+// ErrProFeatureRequired 调用的接口只在商业版中提供
+//
+// 社区版部署调用商业版接口（/api/proApi/）时通常只会得到404，SDK会在这种情况下查询ServerInfo，
+// 确认服务端为社区版后返回包装了该错误和原始错误的错误。
+var ErrProFeatureRequired = errors.New("该功能需要FastGPT商业版")
+
+// serverInfoPath 获取服务端初始化数据的接口路径
+const serverInfoPath = "/api/common/system/getInitData"
+
+// ServerInfo 获取服务端的版本和功能开关
+//
+// 结果在客户端内缓存，只在第一次成功时请求服务端；请求失败时不缓存，下次调用会重试。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//
+// 返回值：
+//
+//	*model.ServerInfo: 服务端信息，调用方不应修改
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	info, err := c.ServerInfo(ctx)
+//	if err == nil && !info.IsPlus() {
+//	    // 隐藏商业版功能入口
+//	}
+func (c *Client) ServerInfo(ctx context.Context) (*model.ServerInfo, error) {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+
+	if c.serverInfo != nil {
+		return c.serverInfo, nil
+	}
+
+	resp, err := c.DoRequestContext(ctx, "GET", serverInfoPath, nil)
+	if err != nil {
+		return nil, err // 请求发送失败，返回错误
+	}
+
+	// 使用parseResponse，避免解析失败时再次进入商业版接口检查
+	var info model.ServerInfo
+	if err := c.parseResponse(resp, &info); err != nil {
+		return nil, wrapRequestError("GET", serverInfoPath, err)
+	}
+
+	c.serverInfo = &info
+	return c.serverInfo, nil
+}
+
+// proFeatureError 商业版接口返回404且服务端为社区版时，返回包装了ErrProFeatureRequired的错误
+//
+// 设置了WithProApiBaseURL时认为已部署商业版服务，不做检查；无法获取ServerInfo时返回原始错误。
+func (c *Client) proFeatureError(ctx context.Context, path string, err error) error {
+	if c.proApiBaseURL != "" || !strings.HasPrefix(path, proApiPathPrefix) {
+		return err
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || (apiErr.StatusCode != 404 && apiErr.Code != 404) {
+		return err
+	}
+
+	info, infoErr := c.ServerInfo(ctx)
+	if infoErr != nil || info.IsPlus() {
+		return err
+	}
+	return fmt.Errorf("%w（服务端为社区版 %s）: %w", ErrProFeatureRequired, info.SystemVersion, err)
+}
@@ -1,6 +1,7 @@
 // Package main 提供FastGPT SDK的使用示例
 //
 // 该示例文件展示了FastGPT SDK的主要功能，包括：
+// 0. 提问并获取回答和引用来源（最常用）
 // 1. 获取应用累积运行结果
 // 2. 获取应用日志看板
 // 3. 发送对话请求
@@ -14,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -32,9 +34,22 @@ func main() {
 	// 方法1：通过SetDebug方法开启debug模式
 	fgpt.SetDebug(true)
 
+	// 示例0：提问并获取回答和引用来源
+	// 最常用的对话方式，无需处理流式事件
+	fmt.Println("=== 示例0：提问 ===")
+	answer, quotes, err := fgpt.Ask(context.Background(), "your-app-id", "FastGPT是什么？")
+	if err != nil {
+		log.Printf("提问失败: %v\n", err)
+	} else {
+		fmt.Printf("回答: %s\n", answer)
+		for _, q := range quotes {
+			fmt.Printf("  来源: %s（相似度 %.2f）\n", q.SourceName, q.Score)
+		}
+	}
+
 	// 示例1：获取应用累积运行结果
 	// 演示如何获取应用的累积运行结果
-	fmt.Println("=== 示例1：获取应用累积运行结果 ===")
+	fmt.Println("\n=== 示例1：获取应用累积运行结果 ===")
 	totalDataReq := &model.AppTotalDataRequest{
 		AppId: "your-app-id", // 应用ID，需替换为实际的应用ID
 	}
@@ -53,25 +68,14 @@ func main() {
 	// 演示如何获取应用的日志看板数据
 	fmt.Println("\n=== 示例2：获取应用日志看板 ===")
 	chartDataReq := &model.AppChartDataRequest{
-		AppId:     "your-app-id",              // 应用ID，需替换为实际的应用ID
-		DateStart: "2025-09-19T16:00:00.000Z", // 开始时间
-		DateEnd:   "2025-09-27T15:59:59.999Z", // 结束时间
-		Offset:    1,                          // 用户留存偏移量
-		Source: []string{
-			"test",
-			"online",
-			"share",
-			"api",
-			"cronJob",
-			"team",
-			"feishu",
-			"official_account",
-			"wecom",
-			"mcp",
-		}, // 日志来源
-		UserTimespan: "day", // 用户数据时间跨度
-		ChatTimespan: "day", // 对话数据时间跨度
-		AppTimespan:  "day", // 应用数据时间跨度
+		AppId:        "your-app-id",              // 应用ID，需替换为实际的应用ID
+		DateStart:    "2025-09-19T16:00:00.000Z", // 开始时间
+		DateEnd:      "2025-09-27T15:59:59.999Z", // 结束时间
+		Offset:       1,                          // 用户留存偏移量
+		Source:       model.AllSources(),         // 日志来源
+		UserTimespan: "day",                      // 用户数据时间跨度
+		ChatTimespan: "day",                      // 对话数据时间跨度
+		AppTimespan:  "day",                      // 应用数据时间跨度
 	}
 	// 调用App.GetChartData方法获取应用日志看板数据
 	chartDataResp, err := fgpt.App.GetChartData(chartDataReq)
@@ -93,8 +97,8 @@ func main() {
 		Detail: false,       // 是否返回中间值
 		Messages: []model.Message{
 			{
-				Role:    "user", // 消息角色，此处为用户
-				Content: "你好",   // 消息内容
+				Role:    "user",                  // 消息角色，此处为用户
+				Content: model.TextContent("你好"), // 消息内容
 			},
 		},
 	}
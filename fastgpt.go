@@ -15,6 +15,9 @@
 //	// 创建客户端实例
 //	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "your-api-key")
 //
+//	// 提问并获取回答和引用来源
+//	answer, quotes, err := fgpt.Ask(ctx, "your-app-id", "FastGPT是什么？")
+//
 //	// 使用各功能模块
 //	// fgpt.App.GetStats(...)
 //	// fgpt.Chat.Chat(...)
@@ -22,10 +25,13 @@
 package fastgpt
 
 import (
+	"context"
+
 	"github.com/xxjwxc/fastgpt/api/app"
 	"github.com/xxjwxc/fastgpt/api/chat"
 	"github.com/xxjwxc/fastgpt/api/dataset"
 	"github.com/xxjwxc/fastgpt/client"
+	"github.com/xxjwxc/fastgpt/model"
 )
 
 // FastGPT 主客户端结构体，封装了所有FastGPT SDK功能
@@ -52,12 +58,156 @@ func (f *FastGPT) SetDebug(debug bool) {
 	f.Client.Debug = debug
 }
 
+// Close 关闭客户端持有的空闲连接
+//
+// 该方法是可选的，但在测试或短生命周期的命令行程序中推荐调用。
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx")
+//	defer fgpt.Close()
+func (f *FastGPT) Close() {
+	f.Client.Close()
+}
+
+// Clone 复制FastGPT实例并应用新的客户端配置
+//
+// 副本使用Client.Clone复制的客户端，并重新创建各API模块，适合多租户服务中
+// 每个请求使用租户自己的API密钥。未设置TLS、代理或连接池相关选项时，副本与原实例共享连接池。
+//
+// 参数：
+//
+//	opts: 覆盖原配置的客户端选项，如client.WithAPIKey、client.WithBaseURL
+//
+// 返回值：
+//
+//	*FastGPT: 新的FastGPT实例
+//
+// 注意事项：
+// - 通过dataset.NewDatasetAPI等方式替换的API模块配置（如WithListCache）不会被复制
+//
+// 使用示例：
+//
+//	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "")
+//	tenant := fgpt.Clone(client.WithAPIKey(tenantKey))
+//	err := tenant.Chat.Chat(req, handler)
+func (f *FastGPT) Clone(opts ...client.Option) *FastGPT {
+	c := f.Client.Clone(opts...)
+
+	return &FastGPT{
+		Client:  c,
+		App:     app.NewAppAPI(c),
+		Chat:    chat.NewChatAPI(c),
+		Dataset: dataset.NewDatasetAPI(c),
+	}
+}
+
+// Ask 向应用提问并返回完整回答和引用来源
+//
+// 这是最常用的对话方式：内部通过Chat.ChatStreamDetail开启Stream和Detail，拼接全部回答内容，
+// 并从flowResponses事件中汇总知识库引用，调用方无需处理SSE事件。
+// 每次调用都会生成新的对话ID，不携带之前的上下文；服务端仍会保存这段对话，每次调用在应用的历史记录中新增一条。
+// 需要多轮对话、对话ID（如之后调用Chat.DeleteHistory删除）或消息ID时请使用AskInChat，
+// 需要变量或更细粒度的事件处理时请使用Chat.ChatContext。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	appId: 应用ID，使用应用专属API密钥时可以为空
+//	question: 用户问题
+//
+// 返回值：
+//
+//	string: 完整回答；出错时为中断前已接收的部分回答
+//	[]model.QuoteItem: 去重后按相似度降序排列的引用列表，未命中知识库时为空
+//	error: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 使用示例：
+//
+//	answer, quotes, err := fgpt.Ask(ctx, "your-app-id", "FastGPT是什么？")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(answer)
+//	for _, q := range quotes {
+//	    fmt.Printf("来源：%s\n", q.SourceName)
+//	}
+func (f *FastGPT) Ask(ctx context.Context, appId, question string) (string, []model.QuoteItem, error) {
+	result, quotes, err := f.AskInChat(ctx, appId, "", question)
+	return result.Text, quotes, err
+}
+
+// AskInChat 在指定对话中提问并返回完整回答、对话ID和引用来源
+//
+// 与Ask相同，但会携带对话ID，服务端据此保存和读取对话上下文。
+// chatId为空时使用Chat.NewChatID生成（可通过chat.WithChatIDGenerator自定义），
+// 调用方保存返回的ChatId后即可继续多轮对话，或在失败后使用同一ID重试。
+// 结果与Chat.ChatStreamDetail相同，包含AI回复消息ID（DataId）、结束原因和token使用情况。
+//
+// 参数：
+//
+//	ctx: 上下文，用于控制超时和取消
+//	appId: 应用ID，使用应用专属API密钥时可以为空
+//	chatId: 对话ID，为空时自动生成
+//	question: 用户问题
+//
+// 返回值：
+//
+//	*model.ChatResult: 对话结果，包含对话ID、消息ID和完整回答，不会为nil；出错时Text为中断前已接收的部分回答
+//	[]model.QuoteItem: 去重后按相似度降序排列的引用列表，未命中知识库时为空
+//	error: 如果请求失败、流处理失败或ctx被取消，返回错误信息
+//
+// 使用示例：
+//
+//	result, _, err := fgpt.AskInChat(ctx, "your-app-id", "", "FastGPT是什么？")
+//	if err != nil {
+//	    return err
+//	}
+//	// 使用同一对话ID继续追问
+//	result, _, err = fgpt.AskInChat(ctx, "your-app-id", result.ChatId, "它支持哪些模型？")
+func (f *FastGPT) AskInChat(ctx context.Context, appId, chatId, question string) (*model.ChatResult, []model.QuoteItem, error) {
+	req := &model.ChatRequest{
+		AppId:  appId,
+		ChatId: chatId, // 为空时由ChatStreamDetail生成
+		Messages: []model.Message{
+			{Role: "user", Content: model.TextContent(question)},
+		},
+	}
+
+	result, err := f.Chat.ChatStreamDetail(ctx, req)
+	if result == nil {
+		return &model.ChatResult{ChatId: chatId}, nil, err // 请求发送前失败
+	}
+	return &result.ChatResult, result.Quotes(), err
+}
+
+// ServerInfo 获取服务端的版本和功能开关
+//
+// 用于判断部署的是社区版还是商业版，应用日志看板、外部文件集合等功能只在商业版中提供。
+// 结果按客户端缓存，Clone得到的副本会重新获取。
+//
+// 返回值：
+//
+//	*model.ServerInfo: 服务端信息
+//	error: 如果请求失败，返回错误信息
+//
+// 使用示例：
+//
+//	info, err := fgpt.ServerInfo()
+//	if err == nil {
+//	    fmt.Printf("FastGPT %s（%s）\n", info.SystemVersion, info.Edition())
+//	}
+func (f *FastGPT) ServerInfo() (*model.ServerInfo, error) {
+	return f.Client.ServerInfo(context.Background())
+}
+
 // NewFastGPT 创建FastGPT客户端实例
 //
 // 参数：
 //
 //	baseURL: FastGPT服务地址，例如：https://cloud.fastgpt.cn
 //	apiKey: 你的API密钥，用于身份验证
+//	opts: 可选的客户端配置项，参见client.Option
 //
 // 返回值：
 //
@@ -66,9 +216,12 @@ func (f *FastGPT) SetDebug(debug bool) {
 // 使用示例：
 //
 //	fgpt := fastgpt.NewFastGPT("https://cloud.fastgpt.cn", "sk-xxx")
-func NewFastGPT(baseURL, apiKey string) *FastGPT {
+//
+//	// 连接使用自签名证书的开发环境（仅限开发调试）
+//	fgpt := fastgpt.NewFastGPT("https://localhost:3000", "sk-xxx", client.WithInsecureSkipVerify())
+func NewFastGPT(baseURL, apiKey string, opts ...client.Option) *FastGPT {
 	// 创建HTTP客户端
-	c := client.NewClient(baseURL, apiKey)
+	c := client.NewClient(baseURL, apiKey, opts...)
 
 	// 初始化各API模块
 	return &FastGPT{
@@ -0,0 +1,62 @@
+package fastgpt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/xxjwxc/fastgpt/api/chat"
+	"github.com/xxjwxc/fastgpt/client/clienttest"
+	"github.com/xxjwxc/fastgpt/model"
+)
+
+func TestAskInChat(t *testing.T) {
+	stream := "event: answer\ndata: {\"id\":\"data1\",\"choices\":[{\"delta\":{\"content\":\"FastGPT是\"}}]}\n\n" +
+		"event: answer\ndata: {\"id\":\"data1\",\"choices\":[{\"delta\":{\"content\":\"知识库问答系统\"},\"finish_reason\":\"stop\"}]}\n\n" +
+		"event: flowResponses\ndata: [{\"moduleName\":\"知识库搜索\",\"quoteList\":[{\"id\":\"q1\",\"sourceName\":\"手册.pdf\",\"score\":0.9}]}]\n\n" +
+		"event: answer\ndata: [DONE]\n\n"
+
+	tests := []struct {
+		name   string
+		chatId string
+	}{
+		{name: "新对话生成对话ID", chatId: ""},
+		{name: "继续已有对话", chatId: "chat1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clienttest.NewFake().On("POST", "/api/v1/chat/completions", http.StatusOK, stream)
+			f := &FastGPT{Chat: chat.NewChatAPI(fake)}
+
+			result, quotes, err := f.AskInChat(context.Background(), "app", tt.chatId, "FastGPT是什么？")
+			if err != nil {
+				t.Fatalf("AskInChat() error = %v", err)
+			}
+			if result.Text != "FastGPT是知识库问答系统" {
+				t.Errorf("Text = %q", result.Text)
+			}
+			if result.DataId != "data1" || result.FinishReason != model.FinishReasonStop {
+				t.Errorf("DataId = %q, FinishReason = %q", result.DataId, result.FinishReason)
+			}
+			if len(quotes) != 1 || quotes[0].SourceName != "手册.pdf" {
+				t.Errorf("quotes = %+v", quotes)
+			}
+
+			var sent model.ChatRequest
+			if err := json.Unmarshal(fake.Calls()[0].Body, &sent); err != nil {
+				t.Fatal(err)
+			}
+			if !sent.Stream || !sent.Detail {
+				t.Errorf("request stream = %v, detail = %v, want both true", sent.Stream, sent.Detail)
+			}
+			if sent.ResponseChatItemId == "" {
+				t.Error("request has no responseChatItemId")
+			}
+			if sent.ChatId == "" || sent.ChatId != result.ChatId || (tt.chatId != "" && sent.ChatId != tt.chatId) {
+				t.Errorf("request chatId = %q, result.ChatId = %q", sent.ChatId, result.ChatId)
+			}
+		})
+	}
+}
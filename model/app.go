@@ -8,6 +8,8 @@
 // 所有模型均使用JSON标签，用于序列化和反序列化API请求和响应。
 package model
 
+import "time"
+
 // AppTotalDataRequest 获取累积运行结果请求模型
 //
 // 用于请求获取应用的累积运行结果。
@@ -29,6 +31,104 @@ type AppTotalDataResponse struct {
 	} `json:"data"` // 响应数据
 }
 
+// AppType 应用类型
+type AppType string
+
+// 应用类型
+const (
+	AppTypeFolder     AppType = "folder"     // 文件夹
+	AppTypeSimple     AppType = "simple"     // 简易应用
+	AppTypeAdvanced   AppType = "advanced"   // 工作流应用
+	AppTypePlugin     AppType = "plugin"     // 插件
+	AppTypeHTTPPlugin AppType = "httpPlugin" // HTTP插件
+)
+
+// AppListRequest 应用列表请求模型
+//
+// 用于请求获取指定文件夹下的应用列表。
+type AppListRequest struct {
+	ParentId  string    `json:"parentId,omitempty"`  // 父级文件夹ID，为空表示根目录
+	Type      []AppType `json:"type,omitempty"`      // 按应用类型过滤，为空表示不过滤
+	SearchKey string    `json:"searchKey,omitempty"` // 按名称搜索
+}
+
+// AppListItem 应用列表项模型
+//
+// 用于表示应用列表中的单个应用或文件夹。
+type AppListItem struct {
+	ID         string  `json:"_id"`        // 应用ID
+	ParentId   *string `json:"parentId"`   // 父级文件夹ID，根目录下为null
+	Avatar     string  `json:"avatar"`     // 头像地址
+	Name       string  `json:"name"`       // 应用名称
+	Intro      string  `json:"intro"`      // 应用介绍
+	Type       AppType `json:"type"`       // 应用类型
+	UpdateTime string  `json:"updateTime"` // 更新时间
+}
+
+// IsFolder 判断是否为文件夹
+func (a AppListItem) IsFolder() bool {
+	return a.Type == AppTypeFolder
+}
+
+// AppTreeNode 应用树节点模型
+//
+// 用于表示按文件夹层级组织的应用，文件夹的Children为其下的应用和子文件夹。
+type AppTreeNode struct {
+	AppListItem
+	Children []*AppTreeNode // 子节点，非文件夹时为空
+}
+
+// ChatSource 对话来源
+type ChatSource string
+
+// 对话来源
+const (
+	ChatSourceTest            ChatSource = "test"             // 调试
+	ChatSourceOnline          ChatSource = "online"           // 在线使用
+	ChatSourceShare           ChatSource = "share"            // 分享链接
+	ChatSourceAPI             ChatSource = "api"              // API调用
+	ChatSourceCronJob         ChatSource = "cronJob"          // 定时任务
+	ChatSourceTeam            ChatSource = "team"             // 团队空间
+	ChatSourceFeishu          ChatSource = "feishu"           // 飞书
+	ChatSourceOfficialAccount ChatSource = "official_account" // 公众号
+	ChatSourceWecom           ChatSource = "wecom"            // 企业微信
+	ChatSourceMcp             ChatSource = "mcp"              // MCP
+)
+
+// AllSources 返回全部已知的对话来源
+//
+// 返回值：
+//
+//	[]ChatSource: 新分配的切片，调用方可以自由修改
+//
+// 使用示例：
+//
+//	req := &model.AppChartDataRequest{AppId: "your-app-id", Source: model.AllSources()}
+func AllSources() []ChatSource {
+	return []ChatSource{
+		ChatSourceTest,
+		ChatSourceOnline,
+		ChatSourceShare,
+		ChatSourceAPI,
+		ChatSourceCronJob,
+		ChatSourceTeam,
+		ChatSourceFeishu,
+		ChatSourceOfficialAccount,
+		ChatSourceWecom,
+		ChatSourceMcp,
+	}
+}
+
+// IsValid 判断是否为已知的对话来源
+func (s ChatSource) IsValid() bool {
+	for _, source := range AllSources() {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
 // SourceCountMap 来源统计映射模型
 //
 // 用于统计不同来源的访问次数，包括测试、线上、分享等渠道。
@@ -104,14 +204,14 @@ type AppData struct {
 //
 // 用于请求获取指定时间范围内的应用日志看板数据。
 type AppChartDataRequest struct {
-	AppId        string   `json:"appId"`        // 应用Id
-	DateStart    string   `json:"dateStart"`    // 开始时间，ISO格式
-	DateEnd      string   `json:"dateEnd"`      // 结束时间，ISO格式
-	Offset       int      `json:"offset"`       // 用户留存偏移量
-	Source       []string `json:"source"`       // 日志来源
-	UserTimespan string   `json:"userTimespan"` // 用户数据时间跨度：day｜week｜month｜quarter
-	ChatTimespan string   `json:"chatTimespan"` // 对话数据时间跨度：day｜week｜month｜quarter
-	AppTimespan  string   `json:"appTimespan"`  // 应用数据时间跨度：day｜week｜month｜quarter
+	AppId        string       `json:"appId"`        // 应用Id
+	DateStart    string       `json:"dateStart"`    // 开始时间，ISO格式
+	DateEnd      string       `json:"dateEnd"`      // 结束时间，ISO格式
+	Offset       int          `json:"offset"`       // 用户留存偏移量
+	Source       []ChatSource `json:"source"`       // 日志来源，可使用AllSources()获取全部来源
+	UserTimespan string       `json:"userTimespan"` // 用户数据时间跨度：day｜week｜month｜quarter
+	ChatTimespan string       `json:"chatTimespan"` // 对话数据时间跨度：day｜week｜month｜quarter
+	AppTimespan  string       `json:"appTimespan"`  // 应用数据时间跨度：day｜week｜month｜quarter
 }
 
 // AppChartDataResponse 获取应用日志看板响应模型
@@ -127,3 +227,85 @@ type AppChartDataResponse struct {
 		AppData  []AppData  `json:"appData"`  // 应用数据数组
 	} `json:"data"` // 响应数据
 }
+
+// TotalPoints 汇总对话数据中的积分消耗
+//
+// 返回值：
+//
+//	float64: ChatData中所有时间点的积分消耗之和
+func (r *AppChartDataResponse) TotalPoints() float64 {
+	var total float64
+	for _, d := range r.Data.ChatData {
+		total += d.Summary.Points
+	}
+	return total
+}
+
+// TotalChats 汇总对话数据中的会话次数
+//
+// 返回值：
+//
+//	int: ChatData中所有时间点的会话次数之和
+func (r *AppChartDataResponse) TotalChats() int {
+	total := 0
+	for _, d := range r.Data.ChatData {
+		total += d.Summary.ChatCount
+	}
+	return total
+}
+
+// AverageResponseTime 计算应用的平均响应时间
+//
+// 返回值：
+//
+//	float64: AppData中总响应时间除以总对话次数，没有对话时返回0
+func (r *AppChartDataResponse) AverageResponseTime() float64 {
+	var totalTime float64
+	chatCount := 0
+	for _, d := range r.Data.AppData {
+		totalTime += d.Summary.TotalResponseTime
+		chatCount += d.Summary.ChatCount
+	}
+	if chatCount == 0 {
+		return 0
+	}
+	return totalTime / float64(chatCount)
+}
+
+// ByDay 按天汇总应用统计数据
+//
+// 将AppData中的时间点按loc时区的日期（格式为"2006-01-02"）分组并累加各项统计，
+// 同一天有多个时间点时合并为一条。
+// FastGPT按服务端所在时区的零点统计每天的数据（官方云服务为UTC+8），
+// 使用UTC分组时这些时间点会落在前一天，因此应传入与服务端一致的时区。
+//
+// 参数：
+//
+//	loc: 划分日期使用的时区，为nil时使用time.Local
+//
+// 返回值：
+//
+//	map[string]AppSummary: 以日期为键的应用统计摘要
+//
+// 使用示例：
+//
+//	loc, _ := time.LoadLocation("Asia/Shanghai")
+//	for day, summary := range resp.ByDay(loc) {
+//	    fmt.Println(day, summary.ChatCount)
+//	}
+func (r *AppChartDataResponse) ByDay(loc *time.Location) map[string]AppSummary {
+	if loc == nil {
+		loc = time.Local
+	}
+	days := make(map[string]AppSummary)
+	for _, d := range r.Data.AppData {
+		day := time.UnixMilli(d.Timestamp).In(loc).Format("2006-01-02")
+		summary := days[day]
+		summary.GoodFeedBackCount += d.Summary.GoodFeedBackCount
+		summary.BadFeedBackCount += d.Summary.BadFeedBackCount
+		summary.ChatCount += d.Summary.ChatCount
+		summary.TotalResponseTime += d.Summary.TotalResponseTime
+		days[day] = summary
+	}
+	return days
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// chartData 构造包含对话数据和应用数据的看板响应
+func chartData(chat []ChatData, app []AppData) *AppChartDataResponse {
+	r := &AppChartDataResponse{}
+	r.Data.ChatData = chat
+	r.Data.AppData = app
+	return r
+}
+
+func TestAppChartDataTotals(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        *AppChartDataResponse
+		wantPoints  float64
+		wantChats   int
+		wantAverage float64
+	}{
+		{name: "没有数据", resp: chartData(nil, nil)},
+		{
+			name: "两个时间点",
+			resp: chartData(
+				[]ChatData{
+					{Timestamp: 1704038400000, Summary: ChatSummary{ChatCount: 2, Points: 1.5}},
+					{Timestamp: 1704124800000, Summary: ChatSummary{ChatCount: 3, Points: 2.5}},
+				},
+				[]AppData{
+					{Timestamp: 1704038400000, Summary: AppSummary{ChatCount: 2, TotalResponseTime: 3}},
+					{Timestamp: 1704124800000, Summary: AppSummary{ChatCount: 2, TotalResponseTime: 5}},
+				},
+			),
+			wantPoints:  4,
+			wantChats:   5,
+			wantAverage: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.TotalPoints(); got != tt.wantPoints {
+				t.Errorf("TotalPoints() = %v, want %v", got, tt.wantPoints)
+			}
+			if got := tt.resp.TotalChats(); got != tt.wantChats {
+				t.Errorf("TotalChats() = %v, want %v", got, tt.wantChats)
+			}
+			if got := tt.resp.AverageResponseTime(); got != tt.wantAverage {
+				t.Errorf("AverageResponseTime() = %v, want %v", got, tt.wantAverage)
+			}
+		})
+	}
+}
+
+func TestAppChartDataByDay(t *testing.T) {
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+	// 2024-01-01 00:00和12:00（UTC+8）以及2024-01-02 00:00（UTC+8）
+	resp := chartData(nil, []AppData{
+		{Timestamp: 1704038400000, Summary: AppSummary{GoodFeedBackCount: 1, ChatCount: 2, TotalResponseTime: 3}},
+		{Timestamp: 1704081600000, Summary: AppSummary{BadFeedBackCount: 1, ChatCount: 1, TotalResponseTime: 1}},
+		{Timestamp: 1704124800000, Summary: AppSummary{ChatCount: 4, TotalResponseTime: 8}},
+	})
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want map[string]AppSummary
+	}{
+		{
+			name: "按UTC+8划分",
+			loc:  shanghai,
+			want: map[string]AppSummary{
+				"2024-01-01": {GoodFeedBackCount: 1, BadFeedBackCount: 1, ChatCount: 3, TotalResponseTime: 4},
+				"2024-01-02": {ChatCount: 4, TotalResponseTime: 8},
+			},
+		},
+		{
+			name: "按UTC划分时零点落在前一天",
+			loc:  time.UTC,
+			want: map[string]AppSummary{
+				"2023-12-31": {GoodFeedBackCount: 1, ChatCount: 2, TotalResponseTime: 3},
+				"2024-01-01": {BadFeedBackCount: 1, ChatCount: 5, TotalResponseTime: 9},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resp.ByDay(tt.loc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ByDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
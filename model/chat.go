@@ -8,10 +8,21 @@
 // 所有模型均使用JSON标签，用于序列化和反序列化API请求和响应。
 package model
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+)
+
 // ChatRequest 对话请求模型
 //
 // 用于向FastGPT发送对话请求，包含应用ID、消息列表和模型配置等。
 type ChatRequest struct {
+	AppId              string                 `json:"appId,omitempty"`              // 应用ID，可选，使用非应用专属的API密钥时指定对话的应用
 	ChatId             string                 `json:"chatId,omitempty"`             // 对话ID，可选，用于使用FastGPT提供的上下文功能
 	Stream             bool                   `json:"stream,omitempty"`             // 是否使用流式响应，默认为false
 	Detail             bool                   `json:"detail,omitempty"`             // 是否返回中间值，默认为false
@@ -24,8 +35,8 @@ type ChatRequest struct {
 //
 // 用于表示对话中的单条消息，包含角色和内容。
 type Message struct {
-	Role    string      `json:"role"`    // 消息角色，可选值：user, assistant, system
-	Content interface{} `json:"content"` // 消息内容，支持字符串或结构化内容
+	Role    string         `json:"role"`    // 消息角色，可选值：user, assistant, system
+	Content MessageContent `json:"content"` // 消息内容，使用TextContent或ItemsContent创建
 }
 
 // ContentItem 结构化内容项
@@ -47,10 +58,71 @@ type ImageURL struct {
 
 // FileURL 文件URL结构体
 //
-// 用于表示消息中的文件URL。
+// 用于表示消息中的文件URL。FastGPT按文件名的扩展名判断文件类型，
+// URL没有扩展名（如S3预签名链接）时，请确保Name带有正确的扩展名。
 type FileURL struct {
-	Name string `json:"name"` // 文件名
-	URL  string `json:"url"`  // 文件URL
+	Name        string `json:"name"`                  // 文件名
+	URL         string `json:"url"`                   // 文件URL
+	ContentType string `json:"contentType,omitempty"` // 可选，文件的MIME类型提示，可通过InferContentType根据文件名推断
+}
+
+// NewFileURL 创建文件URL，并根据文件名推断ContentType
+//
+// 参数：
+//
+//	name: 文件名，应带有扩展名，如"报告.pdf"
+//	url: 文件URL
+//
+// 返回值：
+//
+//	*FileURL: 文件URL，无法推断类型时ContentType为空
+//
+// 使用示例：
+//
+//	item := model.ContentItem{Type: "file_url", FileURL: model.NewFileURL("报告.pdf", presignedURL)}
+func NewFileURL(name, url string) *FileURL {
+	return &FileURL{Name: name, URL: url, ContentType: InferContentType(name)}
+}
+
+// InferContentType 根据文件名的扩展名推断MIME类型
+//
+// 参数：
+//
+//	name: 文件名或带扩展名的路径
+//
+// 返回值：
+//
+//	string: MIME类型，如"application/pdf"，不带charset等参数；无法识别时返回空字符串
+func InferContentType(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if ext == "" {
+		return ""
+	}
+	if t, ok := fileContentTypes[ext]; ok {
+		return t
+	}
+
+	t := mime.TypeByExtension(ext)
+	if i := strings.IndexByte(t, ';'); i >= 0 {
+		t = t[:i]
+	}
+	return strings.TrimSpace(t)
+}
+
+// fileContentTypes 常见文档类型的MIME类型，不依赖系统的MIME数据库
+var fileContentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".txt":  "text/plain",
+	".md":   "text/markdown",
+	".csv":  "text/csv",
+	".html": "text/html",
+	".json": "application/json",
 }
 
 // Delta 增量内容模型
@@ -64,31 +136,58 @@ type Delta struct {
 // Choice 对话选择模型
 //
 // 用于表示对话响应中的选择项，包含增量内容、索引和结束原因。
+// 流式响应的内容位于Delta，非流式响应的内容位于Message。
 type Choice struct {
-	Delta        Delta  `json:"delta"`         // 增量内容
-	Index        int    `json:"index"`         // 选择项索引
-	FinishReason string `json:"finish_reason"` // 结束原因，如stop, length等
+	Delta        Delta    `json:"delta"`             // 增量内容
+	Message      *Message `json:"message,omitempty"` // 完整消息，仅非流式响应返回
+	Index        int      `json:"index"`             // 选择项索引
+	FinishReason string   `json:"finish_reason"`     // 结束原因，如stop, length等
 }
 
+// 回答结束原因
+const (
+	FinishReasonStop   = "stop"   // 正常结束
+	FinishReasonLength = "length" // 达到最大输出长度被截断
+)
+
 // AnswerEvent 回答事件模型
 //
 // 用于表示流式响应中的回答事件，包含生成的内容和相关元数据。
 type AnswerEvent struct {
-	ID      string   `json:"id"`      // 事件ID
-	Object  string   `json:"object"`  // 对象类型，如chat.completion.chunk
-	Created int64    `json:"created"` // 创建时间戳
-	Model   string   `json:"model"`   // 使用的模型名称
-	Choices []Choice `json:"choices"` // 生成的选择项列表
+	ID      string   `json:"id"`              // 事件ID，即AI回复消息ID（responseChatItemId）
+	Object  string   `json:"object"`          // 对象类型，如chat.completion.chunk
+	Created int64    `json:"created"`         // 创建时间戳
+	Model   string   `json:"model"`           // 使用的模型名称
+	Choices []Choice `json:"choices"`         // 生成的选择项列表
+	Usage   *Usage   `json:"usage,omitempty"` // token使用情况，通常只在最后一个回答块中返回
+}
+
+// FinishReason 返回回答块中的结束原因，不是最后一个回答块时返回空字符串
+func (e AnswerEvent) FinishReason() string {
+	for _, choice := range e.Choices {
+		if choice.FinishReason != "" {
+			return choice.FinishReason
+		}
+	}
+	return ""
 }
 
 // FlowNodeStatusEvent 流程节点状态事件模型
 //
 // 用于表示流程节点的状态变化事件，包含节点状态和名称。
 type FlowNodeStatusEvent struct {
-	Status string `json:"status"` // 节点状态，如running, completed, failed等
-	Name   string `json:"name"`   // 节点名称
+	NodeId string `json:"nodeId,omitempty"` // 节点ID，旧版本服务端可能不返回
+	Status string `json:"status"`           // 节点状态，如running, completed, failed等
+	Name   string `json:"name"`             // 节点名称
 }
 
+// 节点状态
+const (
+	NodeStatusRunning   = "running"   // 节点开始运行
+	NodeStatusCompleted = "completed" // 节点运行完成
+	NodeStatusFailed    = "failed"    // 节点运行失败
+)
+
 // HistoryPreview 历史预览模型
 //
 // 用于表示历史对话的预览信息，包含对象类型和值。
@@ -113,6 +212,46 @@ type FlowResponse struct {
 	ContextTotalLen int              `json:"contextTotalLen"`        // 上下文总长度
 	RunningTime     float64          `json:"runningTime"`            // 运行时间，单位秒
 	PluginOutput    interface{}      `json:"pluginOutput,omitempty"` // 插件输出，可选
+	Error           interface{}      `json:"error,omitempty"`        // 节点错误，可能是字符串或包含message的对象
+	ErrorText       string           `json:"errorText,omitempty"`    // 节点错误文本
+	QuoteList       []QuoteItem      `json:"quoteList,omitempty"`    // 知识库搜索节点的引用列表
+}
+
+// ErrorMessage 返回节点的错误信息，节点执行成功时返回空字符串
+//
+// 优先使用errorText，其次解析error字段（字符串或包含message的对象）。
+func (r FlowResponse) ErrorMessage() string {
+	if r.ErrorText != "" {
+		return r.ErrorText
+	}
+
+	switch v := r.Error.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if msg, ok := v["message"].(string); ok && msg != "" {
+			return msg
+		}
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return ""
+}
+
+// NodeError 工作流节点执行错误
+//
+// 节点执行失败时，错误信息通常包含在flowResponses中而不是error事件里，
+// 对话流仍会正常以[DONE]结束。
+type NodeError struct {
+	NodeId     string // 节点ID
+	ModuleName string // 模块名称
+	Message    string // 错误信息
+}
+
+// Error 实现error接口
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("工作流节点 %s 执行失败: %s", e.ModuleName, e.Message)
 }
 
 // FlowResponsesEvent 流程响应事件模型
@@ -122,6 +261,48 @@ type FlowResponsesEvent struct {
 	Responses []FlowResponse `json:"responses"` // 流程响应列表
 }
 
+// UnmarshalJSON 同时支持{"responses":[...]}和直接的数组格式
+//
+// FastGPT在detail模式下的flowResponses事件中直接发送节点响应数组。
+func (e *FlowResponsesEvent) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, &e.Responses)
+	}
+
+	type plain FlowResponsesEvent // 避免递归调用UnmarshalJSON
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// FirstError 返回第一个执行失败的节点错误，所有节点都成功时返回nil
+//
+// 用于发现"对话成功但回答为空"这类被隐藏在流程响应中的节点失败。
+//
+// 使用示例：
+//
+//	if err := flowEvent.FirstError(); err != nil {
+//	    var nodeErr *model.NodeError
+//	    errors.As(err, &nodeErr)
+//	}
+func (e FlowResponsesEvent) FirstError() error {
+	for _, r := range e.Responses {
+		if msg := r.ErrorMessage(); msg != "" {
+			return &NodeError{NodeId: r.NodeId, ModuleName: r.ModuleName, Message: msg}
+		}
+	}
+	return nil
+}
+
+// Quotes 汇总所有节点的引用内容，按ID去重并按相似度分数降序排列
+//
+// 仅在请求开启Detail时，流程响应中才会包含知识库搜索节点的引用列表。
+func (e FlowResponsesEvent) Quotes() []QuoteItem {
+	lists := make([][]QuoteItem, 0, len(e.Responses))
+	for _, r := range e.Responses {
+		lists = append(lists, r.QuoteList)
+	}
+	return mergeQuotes(lists)
+}
+
 // Usage 对话使用情况模型
 //
 // 用于表示对话的token使用情况。
@@ -141,6 +322,51 @@ type ChatResponse struct {
 	Choices []Choice `json:"choices"` // 选择项列表
 }
 
+// Text 返回非流式响应中第一个选择项的回答文本，没有回答时返回空字符串
+func (r ChatResponse) Text() string {
+	for _, choice := range r.Choices {
+		if choice.Message != nil {
+			return choice.Message.Content.String()
+		}
+	}
+	return ""
+}
+
+// ChatResult 流式对话的汇总结果模型
+//
+// 用于表示ChatWithResponseID等辅助方法拼接完整回答后的结果。
+type ChatResult struct {
+	ChatId       string // 对话ID，请求中未设置时为SDK生成的ID
+	DataId       string // AI回复消息ID，可用于UpdateUserFeedback、GetResData等接口
+	Text         string // 完整的回答内容
+	FastAnswer   bool   // 回答是否包含fastAnswer事件（指定回复等即时回答），便于与模型生成的回答区分展示
+	FinishReason string // 最后一个回答块的结束原因，为FinishReasonLength时表示回答因长度限制被截断
+	Usage        *Usage // 回答块中返回的token使用情况，服务端未返回时为nil
+}
+
+// ChatStreamDetailResult 开启Detail的流式对话汇总结果模型
+//
+// 用于表示ChatStreamDetail的结果，在ChatResult的基础上包含flowResponses事件中的节点运行详情，
+// 以及交互节点、工具调用、未知或自定义等其他事件。
+type ChatStreamDetailResult struct {
+	ChatResult
+	FlowResponses []FlowResponse // 各节点的运行详情
+	Events        []StreamEvent  // answer、fastAnswer、flowNodeStatus、flowResponses以外的事件，按接收顺序排列
+}
+
+// StreamEvent 流式对话中的单个事件模型
+//
+// 用于在汇总结果中保留事件名称和数据。
+type StreamEvent struct {
+	Type string      // 事件名称，如"toolCall"、"interactive"或服务端新增的事件
+	Data interface{} // 事件数据，与ChatEventHandler收到的data相同：未注册解码函数的事件为原始JSON字符串
+}
+
+// Quotes 汇总所有节点的引用内容，按ID去重并按相似度分数降序排列
+func (r *ChatStreamDetailResult) Quotes() []QuoteItem {
+	return FlowResponsesEvent{Responses: r.FlowResponses}.Quotes()
+}
+
 // QuoteItem 引用列表项模型
 //
 // 用于表示对话响应中的引用内容。
@@ -156,6 +382,26 @@ type QuoteItem struct {
 	Score        float64 `json:"score,omitempty"`        // 相似度分数
 }
 
+// ResolvedQuote 补全来源信息后的引用模型
+//
+// 在QuoteItem的基础上补充集合和知识库的名称，便于渲染"据《产品手册》"样式的引用标注。
+type ResolvedQuote struct {
+	QuoteItem
+	CollectionName string // 集合名称
+	DatasetName    string // 知识库名称，无法获取时为空
+	RawLink        string // 链接集合的原始链接
+}
+
+// Title 返回用于展示的来源名称
+//
+// 依次使用引用中的来源名称、集合名称，都为空时返回空字符串。
+func (q ResolvedQuote) Title() string {
+	if q.SourceName != "" {
+		return q.SourceName
+	}
+	return q.CollectionName
+}
+
 // CompleteMessage 完整消息模型
 //
 // 用于表示对话响应中的完整消息。
@@ -166,7 +412,7 @@ type CompleteMessage struct {
 
 // ResponseDataItem 响应数据项模型
 //
-// 用于表示带Detail的响应中的数据项。
+// 用于表示带Detail的响应中的数据项。各类节点特有的字段可以通过As解码。
 type ResponseDataItem struct {
 	ModuleName       string            `json:"moduleName"`                 // 模块名称
 	Price            float64           `json:"price,omitempty"`            // 价格
@@ -186,7 +432,9 @@ type ResponseDataItem struct {
 	HistoryPreview   []HistoryPreview  `json:"historyPreview,omitempty"`   // 历史预览
 	ContextTotalLen  int               `json:"contextTotalLen,omitempty"`  // 上下文总长度
 	RunningTime      float64           `json:"runningTime,omitempty"`      // 运行时间
-	PluginOutput     interface{}       `json:"pluginOutput,omitempty"`     // 插件输出
+	PluginOutput     interface{}       `json:"pluginOutput,omitempty"`     // 插件输出，可使用PluginOutputAs或As解码
+
+	raw json.RawMessage // 原始JSON，包含未建模的节点特有字段
 }
 
 // ChatDetailResponse 带Detail的聊天响应模型
@@ -198,12 +446,64 @@ type ChatDetailResponse struct {
 	ChatResponse                        // 嵌入基本聊天响应
 }
 
+// AllQuotes 汇总所有模块的引用内容
+//
+// 引用分散在多个ResponseData的QuoteList中，该方法将其展开后按ID去重，
+// 并按相似度分数从高到低排序，可直接用于渲染引用来源。
+func (r *ChatDetailResponse) AllQuotes() []QuoteItem {
+	return CollectQuotes(r.ResponseData)
+}
+
+// CollectQuotes 从响应数据项中汇总引用内容，按ID去重并按相似度分数降序排列
+//
+// 除ChatDetailResponse外，也可用于ChatAPI.GetResData等返回的响应数据列表。
+//
+// 参数：
+//
+//	items: 响应数据项列表
+//
+// 返回值：
+//
+//	[]QuoteItem: 去重排序后的引用列表
+func CollectQuotes(items []ResponseDataItem) []QuoteItem {
+	lists := make([][]QuoteItem, 0, len(items))
+	for _, item := range items {
+		lists = append(lists, item.QuoteList)
+	}
+	return mergeQuotes(lists)
+}
+
+// mergeQuotes 合并多个引用列表，按ID去重并按相似度分数降序排列
+func mergeQuotes(lists [][]QuoteItem) []QuoteItem {
+	seen := make(map[string]bool)
+	var quotes []QuoteItem
+	for _, list := range lists {
+		for _, quote := range list {
+			key := quote.ID
+			if key == "" {
+				// 没有ID时按来源和内容去重
+				key = quote.CollectionID + "\x00" + quote.Q + "\x00" + quote.A
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			quotes = append(quotes, quote)
+		}
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Score > quotes[j].Score
+	})
+	return quotes
+}
+
 // Interactive 交互节点响应模型
 //
 // 用于表示工作流中交互节点的响应。
 type Interactive struct {
 	Type   string      `json:"type"`   // 交互类型：userSelect, userInput
-	Params interface{} `json:"params"` // 交互参数，根据type不同而不同
+	Params interface{} `json:"params"` // 交互参数，根据type不同而不同，可通过UserSelectParams、UserInputParams解析
 }
 
 // UserSelectParams 用户选择参数模型
@@ -257,10 +557,10 @@ type UserInputParams struct {
 //
 // 用于请求获取应用的历史对话记录。
 type GetHistoriesRequest struct {
-	AppId    string `json:"appId"`    // 应用ID
-	Offset   int    `json:"offset"`   // 偏移量
-	PageSize int    `json:"pageSize"` // 每页数量
-	Source   string `json:"source"`   // 对话源，如api
+	AppId    string     `json:"appId"`    // 应用ID
+	Offset   int        `json:"offset"`   // 偏移量
+	PageSize int        `json:"pageSize"` // 每页数量
+	Source   ChatSource `json:"source"`   // 对话源，如ChatSourceAPI
 }
 
 // ChatHistory 聊天历史记录模型
@@ -286,11 +586,25 @@ type GetHistoriesResponse struct {
 // UpdateHistoryRequest 更新历史记录请求模型
 //
 // 用于更新对话历史记录，如修改标题或置顶状态。
+//
+// Top为三态字段：
+// - nil：不发送top字段，保持原置顶状态不变
+// - Bool(true)：发送"top":true，置顶
+// - Bool(false)：发送"top":false，取消置顶
 type UpdateHistoryRequest struct {
 	AppId       string `json:"appId"`                 // 应用ID
 	ChatId      string `json:"chatId"`                // 对话ID
 	CustomTitle string `json:"customTitle,omitempty"` // 自定义标题
-	Top         *bool  `json:"top,omitempty"`         // 是否置顶
+	Top         *bool  `json:"top,omitempty"`         // 是否置顶，nil表示不修改
+}
+
+// Bool 返回指向v的指针，便于设置UpdateHistoryRequest.Top等三态字段
+//
+// 使用示例：
+//
+//	req := &model.UpdateHistoryRequest{AppId: "app", ChatId: "chat", Top: model.Bool(false)}
+func Bool(v bool) *bool {
+	return &v
 }
 
 // ChatInitResponse 获取对话初始化信息响应模型
@@ -307,13 +621,13 @@ type ChatInitResponse struct {
 //
 // 用于表示对话初始化信息中的应用信息。
 type ChatAppInfo struct {
-	ChatConfig   ChatConfig `json:"chatConfig"`   // 聊天配置
-	ChatModels   []string   `json:"chatModels"`   // 聊天模型列表
-	Name         string     `json:"name"`         // 应用名称
-	Avatar       string     `json:"avatar"`       // 应用头像
-	Intro        string     `json:"intro"`        // 应用介绍
-	Type         string     `json:"type"`         // 应用类型
-	PluginInputs []string   `json:"pluginInputs"` // 插件输入列表
+	ChatConfig   ChatConfig    `json:"chatConfig"`   // 聊天配置
+	ChatModels   []string      `json:"chatModels"`   // 聊天模型列表
+	Name         string        `json:"name"`         // 应用名称
+	Avatar       string        `json:"avatar"`       // 应用头像
+	Intro        string        `json:"intro"`        // 应用介绍
+	Type         string        `json:"type"`         // 应用类型
+	PluginInputs []PluginInput `json:"pluginInputs"` // 插件输入列表
 }
 
 // ChatConfig 聊天配置模型
@@ -325,7 +639,7 @@ type ChatConfig struct {
 	WhisperConfig    WhisperConfig    `json:"whisperConfig"`    // Whisper配置
 	ChatInputGuide   ChatInputGuide   `json:"chatInputGuide"`   // 聊天输入引导
 	Instruction      string           `json:"instruction"`      // 指令
-	Variables        []interface{}    `json:"variables"`        // 变量列表
+	Variables        []VariableDef    `json:"variables"`        // 全局变量定义列表
 	FileSelectConfig FileSelectConfig `json:"fileSelectConfig"` // 文件选择配置
 	WelcomeText      string           `json:"welcomeText"`      // 欢迎文本
 }
@@ -390,6 +704,45 @@ type ChatRecord struct {
 	HistoryPreviewLength int           `json:"historyPreviewLength,omitempty"` // 历史预览长度
 }
 
+// Text 提取记录中的文本内容
+//
+// 记录值通常是{"type":"text","text":{"content":"..."}}组成的数组，工具调用、推理过程等
+// 非文本项会被忽略；记录值为字符串时直接返回。
+//
+// 返回值：
+//
+//	string: 按顺序拼接的文本内容
+func (r ChatRecord) Text() string {
+	switch value := r.Value.(type) {
+	case string:
+		return value
+	case []interface{}:
+		var b strings.Builder
+		for _, item := range value {
+			m, ok := item.(map[string]interface{})
+			if !ok || m["type"] != "text" {
+				continue
+			}
+			if text, ok := m["text"].(map[string]interface{}); ok {
+				content, _ := text["content"].(string)
+				b.WriteString(content)
+			}
+		}
+		return b.String()
+	}
+	return ""
+}
+
+// ChatRecordDetail 单条对话记录详情模型
+//
+// 将AI回复的记录、文本内容和完整响应数据（含引用）合并在一起，便于展示消息详情。
+type ChatRecordDetail struct {
+	Record       ChatRecord         // 对话记录
+	Text         string             // 回复的文本内容
+	ResponseData []ResponseDataItem // 各节点的响应数据
+	Quotes       []QuoteItem        // 去重后的引用列表
+}
+
 // GetPaginationRecordsResponse 获取对话记录列表响应模型
 //
 // 用于表示获取对话记录列表的响应。
@@ -398,6 +751,45 @@ type GetPaginationRecordsResponse struct {
 	Total int          `json:"total"` // 总记录数
 }
 
+// ToMessages 将对话记录转换为对话请求使用的消息列表
+//
+// Human记录转换为user消息，AI记录转换为assistant消息，System记录转换为system消息，
+// 内容取记录中的文本（见ChatRecord.Text），其他类型或没有文本的记录会被跳过。
+// 单页记录本身按从早到晚排列，转换后保持原顺序；需要完整历史时可将ChatAPI.ResumeChat
+// 返回的记录放入该结构后再转换。
+//
+// 返回值：
+//
+//	[]Message: 从早到晚排列的消息列表，可直接用于ChatRequest.Messages
+//
+// 使用示例：
+//
+//	records, _ := chatAPI.GetPaginationRecords(req)
+//	messages := append(records.ToMessages(), model.Message{Role: "user", Content: model.TextContent("继续")})
+func (r *GetPaginationRecordsResponse) ToMessages() []Message {
+	messages := make([]Message, 0, len(r.List))
+	for _, record := range r.List {
+		var role string
+		switch record.Obj {
+		case "Human":
+			role = "user"
+		case "AI":
+			role = "assistant"
+		case "System":
+			role = "system"
+		default:
+			continue
+		}
+
+		text := record.Text()
+		if text == "" {
+			continue
+		}
+		messages = append(messages, Message{Role: role, Content: TextContent(text)})
+	}
+	return messages
+}
+
 // UpdateUserFeedbackRequest 更新用户反馈请求模型
 //
 // 用于更新用户对对话记录的反馈，如点赞或点踩。
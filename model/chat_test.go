@@ -0,0 +1,97 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestUpdateHistoryRequestTop(t *testing.T) {
+	tests := []struct {
+		name string
+		top  *bool
+		want string
+	}{
+		{name: "不修改置顶状态", top: nil, want: `{"appId":"app","chatId":"chat"}`},
+		{name: "置顶", top: Bool(true), want: `{"appId":"app","chatId":"chat","top":true}`},
+		{name: "取消置顶", top: Bool(false), want: `{"appId":"app","chatId":"chat","top":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(UpdateHistoryRequest{AppId: "app", ChatId: "chat", Top: tt.top})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlowResponsesEventFirstError(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *NodeError // 为nil时期望没有错误
+		wantLen int
+	}{
+		{
+			name:    "error为字符串",
+			data:    `{"responses":[{"nodeId":"n1","moduleName":"知识库搜索"},{"nodeId":"n2","moduleName":"AI 对话","error":"模型不可用"}]}`,
+			want:    &NodeError{NodeId: "n2", ModuleName: "AI 对话", Message: "模型不可用"},
+			wantLen: 2,
+		},
+		{
+			name:    "error为包含message的对象",
+			data:    `{"responses":[{"nodeId":"n1","moduleName":"HTTP 请求","error":{"message":"请求超时","code":504}}]}`,
+			want:    &NodeError{NodeId: "n1", ModuleName: "HTTP 请求", Message: "请求超时"},
+			wantLen: 1,
+		},
+		{
+			name:    "errorText优先",
+			data:    `{"responses":[{"nodeId":"n1","moduleName":"代码运行","errorText":"语法错误","error":"忽略"}]}`,
+			want:    &NodeError{NodeId: "n1", ModuleName: "代码运行", Message: "语法错误"},
+			wantLen: 1,
+		},
+		{
+			name:    "直接的数组格式",
+			data:    `[{"nodeId":"n1","moduleName":"AI 对话"},{"nodeId":"n2","moduleName":"工具调用","error":"工具不存在"}]`,
+			want:    &NodeError{NodeId: "n2", ModuleName: "工具调用", Message: "工具不存在"},
+			wantLen: 2,
+		},
+		{
+			name:    "所有节点成功",
+			data:    `[{"nodeId":"n1","moduleName":"AI 对话"}]`,
+			wantLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event FlowResponsesEvent
+			if err := json.Unmarshal([]byte(tt.data), &event); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if len(event.Responses) != tt.wantLen {
+				t.Fatalf("got %d responses, want %d", len(event.Responses), tt.wantLen)
+			}
+
+			err := event.FirstError()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("FirstError() = %v, want nil", err)
+				}
+				return
+			}
+			var nodeErr *NodeError
+			if !errors.As(err, &nodeErr) {
+				t.Fatalf("FirstError() = %v, want *NodeError", err)
+			}
+			if *nodeErr != *tt.want {
+				t.Errorf("FirstError() = %+v, want %+v", *nodeErr, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package model
+
+import "fmt"
+
+// ChunkSettings 集合创建时的分块参数
+//
+// 纯文本、链接、API文件和外部文件集合的创建请求都有一组分块参数，字段名相同但外部文件集合的参数较少。
+// 使用预设并通过ApplyChunkSettings写入请求，可以让不同类型的集合保持一致的分块方式。
+type ChunkSettings struct {
+	TrainingType     string // 数据处理方式：chunk, qa
+	ChunkSettingMode string // 分块参数模式：auto, custom
+	ChunkSplitMode   string // 分块拆分模式：size, char
+	ChunkSize        int    // 分块大小
+	IndexSize        int    // 索引大小
+	ChunkSplitter    string // 自定义最高优先分割符号
+	QAPrompt         string // qa拆分提示词
+}
+
+// ChunkPresetDefault 返回默认分块预设
+//
+// 按文本长度分块，分块参数由服务端根据知识库的向量模型自动选择，适合大多数文档。
+func ChunkPresetDefault() ChunkSettings {
+	return ChunkSettings{
+		TrainingType:     "chunk",
+		ChunkSettingMode: "auto",
+	}
+}
+
+// ChunkPresetLargeContext 返回大分块预设
+//
+// 使用3000字符的分块和1024的索引大小，每个分块保留更完整的上下文，
+// 适合章节较长的手册、合同等文档，以及上下文窗口较大的对话模型。
+// 索引大小不能超过向量模型支持的最大长度，使用较小的向量模型时请自行调小IndexSize。
+func ChunkPresetLargeContext() ChunkSettings {
+	return ChunkSettings{
+		TrainingType:     "chunk",
+		ChunkSettingMode: "custom",
+		ChunkSplitMode:   "size",
+		ChunkSize:        3000,
+		IndexSize:        1024,
+	}
+}
+
+// ChunkPresetQA 返回问答对提取预设
+//
+// 由文本处理模型从每个分块中提取问答对，分块大小为8000字符，需要文本处理模型的上下文足够大。
+//
+// 参数：
+//
+//	prompt: qa拆分提示词，为空时使用服务端默认提示词
+func ChunkPresetQA(prompt string) ChunkSettings {
+	return ChunkSettings{
+		TrainingType:     "qa",
+		ChunkSettingMode: "custom",
+		ChunkSplitMode:   "size",
+		ChunkSize:        8000,
+		QAPrompt:         prompt,
+	}
+}
+
+// ApplyChunkSettings 将分块参数写入集合创建请求
+//
+// 预设中非零值的字段会覆盖请求中的对应字段，请求类型没有的字段会被忽略
+// （如外部文件集合没有ChunkSettingMode、ChunkSplitMode和IndexSize）。
+// 需要调整个别参数时，先调用该函数，再修改请求中的字段。
+//
+// 参数：
+//
+//	req: 集合创建请求，支持*CollectionCreateTextRequest、*CollectionCreateLinkRequest、
+//	     *CollectionCreateAPRequest和*CollectionCreateExternalFileRequest
+//	s: 分块参数，通常来自ChunkPresetDefault等预设
+//
+// 返回值：
+//
+//	error: 请求类型不支持时返回错误
+//
+// 使用示例：
+//
+//	req := &model.CollectionCreateLinkRequest{Link: "https://example.com/doc", DatasetId: "your-dataset-id"}
+//	if err := model.ApplyChunkSettings(req, model.ChunkPresetLargeContext()); err != nil {
+//	    return err
+//	}
+//	req.ChunkSplitter = "\n## " // 在预设基础上覆盖个别参数
+func ApplyChunkSettings(req interface{}, s ChunkSettings) error {
+	switch r := req.(type) {
+	case *CollectionCreateTextRequest:
+		setString(&r.TrainingType, s.TrainingType)
+		setString(&r.ChunkSettingMode, s.ChunkSettingMode)
+		setString(&r.ChunkSplitMode, s.ChunkSplitMode)
+		setInt(&r.ChunkSize, s.ChunkSize)
+		setInt(&r.IndexSize, s.IndexSize)
+		setString(&r.ChunkSplitter, s.ChunkSplitter)
+		setString(&r.QAPrompt, s.QAPrompt)
+	case *CollectionCreateLinkRequest:
+		setString(&r.TrainingType, s.TrainingType)
+		setString(&r.ChunkSettingMode, s.ChunkSettingMode)
+		setString(&r.ChunkSplitMode, s.ChunkSplitMode)
+		setInt(&r.ChunkSize, s.ChunkSize)
+		setInt(&r.IndexSize, s.IndexSize)
+		setString(&r.ChunkSplitter, s.ChunkSplitter)
+		setString(&r.QAPrompt, s.QAPrompt)
+	case *CollectionCreateAPRequest:
+		setString(&r.TrainingType, s.TrainingType)
+		setString(&r.ChunkSettingMode, s.ChunkSettingMode)
+		setString(&r.ChunkSplitMode, s.ChunkSplitMode)
+		setInt(&r.ChunkSize, s.ChunkSize)
+		setInt(&r.IndexSize, s.IndexSize)
+		setString(&r.ChunkSplitter, s.ChunkSplitter)
+		setString(&r.QAPrompt, s.QAPrompt)
+	case *CollectionCreateExternalFileRequest:
+		setString(&r.TrainingType, s.TrainingType)
+		setInt(&r.ChunkSize, s.ChunkSize)
+		setString(&r.ChunkSplitter, s.ChunkSplitter)
+		setString(&r.QAPrompt, s.QAPrompt)
+	default:
+		return fmt.Errorf("不支持的集合创建请求类型: %T", req)
+	}
+	return nil
+}
+
+// setString 值非空时写入目标字段
+func setString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+// setInt 值非零时写入目标字段
+func setInt(dst *int, value int) {
+	if value != 0 {
+		*dst = value
+	}
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MessageContent 消息内容
+//
+// 消息内容在接口中可能是字符串，也可能是结构化内容项数组（多模态消息）。
+// 该类型在序列化和反序列化时自动区分两种格式，读取时通过IsText判断，无需再做类型断言。
+//
+// 使用示例：
+//
+//	// 发送纯文本消息
+//	msg := model.Message{Role: "user", Content: model.TextContent("你好")}
+//
+//	// 发送图片和文本
+//	msg := model.Message{Role: "user", Content: model.ItemsContent(
+//	    model.ContentItem{Type: "text", Text: "这张图片里有什么？"},
+//	    model.ContentItem{Type: "image_url", ImageURL: &model.ImageURL{URL: imageURL}},
+//	)}
+//
+//	// 读取内容
+//	if msg.Content.IsText {
+//	    fmt.Println(msg.Content.Text)
+//	} else {
+//	    for _, item := range msg.Content.Items { ... }
+//	}
+type MessageContent struct {
+	IsText bool          // 是否为字符串内容
+	Text   string        // 字符串内容，IsText为true时使用
+	Items  []ContentItem // 结构化内容项，IsText为false时使用
+}
+
+// TextContent 创建字符串消息内容
+func TextContent(text string) MessageContent {
+	return MessageContent{IsText: true, Text: text}
+}
+
+// ItemsContent 创建结构化消息内容
+func ItemsContent(items ...ContentItem) MessageContent {
+	return MessageContent{Items: items}
+}
+
+// String 返回内容中的全部文本
+//
+// 字符串内容直接返回；结构化内容返回所有text类型内容项的文本，以换行连接。
+func (c MessageContent) String() string {
+	if c.IsText {
+		return c.Text
+	}
+
+	var texts []string
+	for _, item := range c.Items {
+		if item.Type == "text" && item.Text != "" {
+			texts = append(texts, item.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// MarshalJSON 字符串内容序列化为JSON字符串，结构化内容序列化为数组
+//
+// 零值序列化为空字符串。
+func (c MessageContent) MarshalJSON() ([]byte, error) {
+	if c.IsText || c.Items == nil {
+		return json.Marshal(c.Text)
+	}
+	return json.Marshal(c.Items)
+}
+
+// UnmarshalJSON 支持字符串、内容项数组和单个内容项对象
+func (c *MessageContent) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	*c = MessageContent{}
+
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil
+	case data[0] == '"':
+		c.IsText = true
+		return json.Unmarshal(data, &c.Text)
+	case data[0] == '[':
+		return json.Unmarshal(data, &c.Items)
+	case data[0] == '{':
+		var item ContentItem
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		c.Items = []ContentItem{item}
+		return nil
+	}
+	return fmt.Errorf("无法解析消息内容: %s", data)
+}
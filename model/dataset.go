@@ -8,7 +8,13 @@
 // 所有模型均使用JSON标签，用于序列化和反序列化API请求和响应。
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // BaseResponse 基础响应模型
 //
@@ -36,7 +42,7 @@ type DatasetTrainOrderRequest struct {
 //
 // 用于请求创建一个新的知识库。
 type DatasetCreateRequest struct {
-	ParentId    *string `json:"parentId,omitempty"`    // 父级ID，用于构建目录结构
+	ParentId    *string `json:"parentId,omitempty"`    // 父级ID，nil表示根目录，可使用model.Parent构造
 	Type        string  `json:"type,omitempty"`        // dataset或者folder，代表普通知识库和文件夹
 	Name        string  `json:"name"`                  // 知识库名（必填）
 	Intro       string  `json:"intro,omitempty"`       // 介绍（可选）
@@ -82,7 +88,7 @@ type DatasetInfo struct {
 	Permission  string      `json:"permission"`           // 权限
 	CanWrite    bool        `json:"canWrite"`             // 是否可写
 	IsOwner     bool        `json:"isOwner"`              // 是否是所有者
-	VectorModel VectorModel `json:"vectorModel"`          // 向量模型信息
+	VectorModel VectorModel `json:"vectorModel"`          // 向量模型信息，响应未返回时为零值，见HasVectorModel
 	AgentModel  *AgentModel `json:"agentModel,omitempty"` // 文本处理模型信息
 	Status      string      `json:"status,omitempty"`     // 状态
 	TeamId      string      `json:"teamId,omitempty"`     // 团队ID
@@ -90,6 +96,19 @@ type DatasetInfo struct {
 	UpdateTime  string      `json:"updateTime,omitempty"` // 更新时间
 }
 
+// HasVectorModel 判断响应中是否包含向量模型信息
+//
+// 部分接口的响应不返回vectorModel字段，此时VectorModel为零值，
+// 其Model为空字符串并不代表使用了名为""的模型。读取VectorModel前应先调用本方法。
+// 为保持兼容，VectorModel暂不改为指针类型。
+//
+// 返回值：
+//
+//	bool: 包含向量模型信息返回true
+func (d DatasetInfo) HasVectorModel() bool {
+	return d.VectorModel.Model != ""
+}
+
 // DatasetListRequest 知识库列表请求模型
 //
 // 用于请求获取知识库列表。
@@ -113,14 +132,55 @@ type DatasetDeleteRequest struct {
 
 // 集合相关模型
 
+// CollectionType 集合类型
+type CollectionType string
+
+// 集合类型
+const (
+	CollectionTypeFolder       CollectionType = "folder"       // 文件夹
+	CollectionTypeVirtual      CollectionType = "virtual"      // 手动录入或纯文本集合
+	CollectionTypeFile         CollectionType = "file"         // 本地文件
+	CollectionTypeLink         CollectionType = "link"         // 网页链接
+	CollectionTypeExternalFile CollectionType = "externalFile" // 外部文件
+	CollectionTypeAPIFile      CollectionType = "apiFile"      // API文件库
+	CollectionTypeImages       CollectionType = "images"       // 图片集
+)
+
+// AllCollectionTypes 返回全部已知的集合类型
+//
+// 返回值：
+//
+//	[]CollectionType: 新分配的切片，调用方可以自由修改
+func AllCollectionTypes() []CollectionType {
+	return []CollectionType{
+		CollectionTypeFolder,
+		CollectionTypeVirtual,
+		CollectionTypeFile,
+		CollectionTypeLink,
+		CollectionTypeExternalFile,
+		CollectionTypeAPIFile,
+		CollectionTypeImages,
+	}
+}
+
+// IsValid 判断是否为已知的集合类型，不会忽略大小写或首尾空格
+func (t CollectionType) IsValid() bool {
+	for _, collectionType := range AllCollectionTypes() {
+		if t == collectionType {
+			return true
+		}
+	}
+	return false
+}
+
 // CollectionCreateRequest 集合创建请求模型
 //
 // 用于请求创建一个空的集合。
 type CollectionCreateRequest struct {
 	DatasetId string                 `json:"datasetId"`          // 知识库的ID(必填)
-	ParentId  *string                `json:"parentId,omitempty"` // 父级ID，不填则默认为根目录
+	ParentId  *string                `json:"parentId,omitempty"` // 父级ID，nil表示根目录，不要指向空字符串
 	Name      string                 `json:"name"`               // 集合名称（必填）
-	Type      string                 `json:"type"`               // 集合类型：folder, virtual
+	Type      CollectionType         `json:"type"`               // 集合类型：CollectionTypeFolder, CollectionTypeVirtual
 	Metadata  map[string]interface{} `json:"metadata,omitempty"` // 元数据
 }
 
@@ -130,7 +190,7 @@ type CollectionCreateRequest struct {
 type CollectionCreateTextRequest struct {
 	Text             string                 `json:"text"`                       // 原文本
 	DatasetId        string                 `json:"datasetId"`                  // 知识库的ID(必填)
-	ParentId         *string                `json:"parentId,omitempty"`         // 父级ID，不填则默认为根目录
+	ParentId         *string                `json:"parentId,omitempty"`         // 父级ID，nil表示根目录，不要指向空字符串
 	Name             string                 `json:"name"`                       // 集合名称（必填）
 	TrainingType     string                 `json:"trainingType"`               // 数据处理方式：chunk, qa
 	ChunkSettingMode string                 `json:"chunkSettingMode,omitempty"` // 分块参数模式：auto, custom
@@ -142,13 +202,72 @@ type CollectionCreateTextRequest struct {
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`         // 元数据
 }
 
+// CollectionMetadata 集合元数据模型
+//
+// 用于以类型安全的方式设置集合元数据中的常用字段，序列化后与Metadata的扁平对象格式一致。
+type CollectionMetadata struct {
+	Source         string                 // 来源标识，对应metadata.source
+	SourceId       string                 // 外部系统中的来源ID，对应metadata.sourceId
+	RelatedImageId string                 // 关联图片ID，对应metadata.relatedImgId
+	Extra          map[string]interface{} // 其他自定义字段，与上述字段平铺在同一对象中
+}
+
+// ToMap 将元数据转换为扁平的map格式
+//
+// Extra中的同名键会被Source、SourceId、RelatedImageId覆盖；空字段不会写入。
+//
+// 返回值：
+//
+//	map[string]interface{}: 可直接赋值给Metadata字段的元数据，没有任何字段时返回nil
+func (m CollectionMetadata) ToMap() map[string]interface{} {
+	result := make(map[string]interface{}, len(m.Extra)+3)
+	for k, v := range m.Extra {
+		result[k] = v
+	}
+	if m.Source != "" {
+		result["source"] = m.Source
+	}
+	if m.SourceId != "" {
+		result["sourceId"] = m.SourceId
+	}
+	if m.RelatedImageId != "" {
+		result["relatedImgId"] = m.RelatedImageId
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// SetMetadata 设置集合元数据
+//
+// 参数：
+//
+//	m: 集合元数据，会覆盖已有的Metadata
+//
+// 返回值：
+//
+//	*CollectionCreateTextRequest: 当前请求，便于链式调用
+//
+// 使用示例：
+//
+//	req.SetMetadata(model.CollectionMetadata{
+//	    Source:   "crm",
+//	    SourceId: "ticket-1024",
+//	    Extra:    map[string]interface{}{"owner": "alice"},
+//	})
+func (r *CollectionCreateTextRequest) SetMetadata(m CollectionMetadata) *CollectionCreateTextRequest {
+	r.Metadata = m.ToMap()
+	return r
+}
+
 // CollectionCreateLinkRequest 链接集合创建请求模型
 //
 // 用于请求创建一个链接集合。
 type CollectionCreateLinkRequest struct {
 	Link             string                 `json:"link"`                       // 网络链接
 	DatasetId        string                 `json:"datasetId"`                  // 知识库的ID(必填)
-	ParentId         *string                `json:"parentId,omitempty"`         // 父级ID，不填则默认为根目录
+	ParentId         *string                `json:"parentId,omitempty"`         // 父级ID，nil表示根目录，不要指向空字符串
 	TrainingType     string                 `json:"trainingType"`               // 数据处理方式：chunk, qa
 	ChunkSettingMode string                 `json:"chunkSettingMode,omitempty"` // 分块参数模式：auto, custom
 	ChunkSplitMode   string                 `json:"chunkSplitMode,omitempty"`   // 分块拆分模式：size, char
@@ -163,14 +282,42 @@ type CollectionCreateLinkRequest struct {
 //
 // 用于请求创建一个API集合。
 type CollectionCreateAPRequest struct {
-	Name          string  `json:"name"`                    // 集合名，建议就用文件名，必填
-	ApiFileId     string  `json:"apiFileId"`               // 文件的ID，必填
-	DatasetId     string  `json:"datasetId"`               // 知识库的ID(必填)
-	ParentId      *string `json:"parentId,omitempty"`      // 父级ID，不填则默认为根目录
-	TrainingType  string  `json:"trainingType"`            // 训练模式（必填）
-	ChunkSize     int     `json:"chunkSize,omitempty"`     // 每个chunk的长度
-	ChunkSplitter string  `json:"chunkSplitter,omitempty"` // 自定义最高优先分割符号
-	QAPrompt      string  `json:"qaPrompt,omitempty"`      // qa拆分自定义提示词
+	Name             string  `json:"name"`                       // 集合名，建议就用文件名，必填
+	ApiFileId        string  `json:"apiFileId"`                  // 文件的ID，必填
+	DatasetId        string  `json:"datasetId"`                  // 知识库的ID(必填)
+	ParentId         *string `json:"parentId,omitempty"`         // 父级ID，nil表示根目录，不要指向空字符串
+	TrainingType     string  `json:"trainingType"`               // 训练模式（必填）
+	ChunkSettingMode string  `json:"chunkSettingMode,omitempty"` // 分块参数模式：auto, custom
+	ChunkSplitMode   string  `json:"chunkSplitMode,omitempty"`   // 分块拆分模式：size, char
+	ChunkSize        int     `json:"chunkSize,omitempty"`        // 每个chunk的长度
+	IndexSize        int     `json:"indexSize,omitempty"`        // 索引大小
+	ChunkSplitter    string  `json:"chunkSplitter,omitempty"`    // 自定义最高优先分割符号
+	QAPrompt         string  `json:"qaPrompt,omitempty"`         // qa拆分自定义提示词
+}
+
+// CollectionCreateFileRequest 本地文件集合创建请求模型
+//
+// 用于上传本地文件创建集合。文件以multipart/form-data的file字段上传，集合名称为文件名；
+// 其余参数序列化为JSON后放在data字段中。
+type CollectionCreateFileRequest struct {
+	FilePath         string                 `json:"-"`                          // 本地文件路径（必填）
+	DatasetId        string                 `json:"datasetId"`                  // 知识库的ID(必填)
+	ParentId         *string                `json:"parentId,omitempty"`         // 父级ID，nil表示根目录，不要指向空字符串
+	TrainingType     string                 `json:"trainingType"`               // 数据处理方式：chunk, qa
+	ChunkSettingMode string                 `json:"chunkSettingMode,omitempty"` // 分块参数模式：auto, custom
+	ChunkSplitMode   string                 `json:"chunkSplitMode,omitempty"`   // 分块拆分模式：size, char
+	ChunkSize        int                    `json:"chunkSize,omitempty"`        // 分块大小
+	IndexSize        int                    `json:"indexSize,omitempty"`        // 索引大小
+	ChunkSplitter    string                 `json:"chunkSplitter,omitempty"`    // 自定义最高优先分割符号
+	QAPrompt         string                 `json:"qaPrompt,omitempty"`         // qa拆分提示词
+	Tags             []string               `json:"tags,omitempty"`             // 集合标签
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`         // 元数据
+
+	// OnUploadProgress 上传进度回调，可以为nil
+	//
+	// 每次读取文件内容后调用，bytesSent为已发送的文件字节数，total为文件大小，无法获取大小时为-1。
+	// 401重试时文件会重新上传，bytesSent从0开始重新计数。
+	OnUploadProgress func(bytesSent, total int64) `json:"-"`
 }
 
 // CollectionCreateExternalFileRequest 外部文件集合创建请求模型
@@ -182,7 +329,7 @@ type CollectionCreateExternalFileRequest struct {
 	Filename        string   `json:"filename,omitempty"`       // 自定义文件名，需要带后缀
 	CreateTime      string   `json:"createTime,omitempty"`     // 文件创建时间
 	DatasetId       string   `json:"datasetId"`                // 知识库的ID(必填)
-	ParentId        *string  `json:"parentId,omitempty"`       // 父级ID，不填则默认为根目录
+	ParentId        *string  `json:"parentId,omitempty"`       // 父级ID，nil表示根目录，不要指向空字符串
 	Tags            []string `json:"tags,omitempty"`           // 集合标签
 	TrainingType    string   `json:"trainingType"`             // 数据处理方式：chunk, qa
 	ChunkSize       int      `json:"chunkSize,omitempty"`      // 分块大小
@@ -190,16 +337,62 @@ type CollectionCreateExternalFileRequest struct {
 	QAPrompt        string   `json:"qaPrompt,omitempty"`       // qa拆分提示词
 }
 
-// CollectionCreateResult 集合创建结果模型
+// IngestResult 数据写入结果模型
 //
-// 用于表示集合创建的结果。
-type CollectionCreateResult struct {
-	InsertLen int      `json:"insertLen"` // 插入的块数量
+// 集合创建和数据推送接口返回相同结构的写入结果，统一使用该类型表示，便于复用处理和日志逻辑。
+// 服务端只返回写入数量，不返回新数据的ID，数据在训练完成后才会生成ID。
+type IngestResult struct {
+	InsertLen int      `json:"insertLen"` // 最终插入成功的数量
 	OverToken []string `json:"overToken"` // 超出token的项
 	Repeat    []string `json:"repeat"`    // 重复的项
-	Error     []string `json:"error"`     // 错误的项
+	Error     []string `json:"error"`     // 其他错误的项
+}
+
+// Add 将另一次写入结果累加到当前结果
+//
+// 参数：
+//
+//	other: 需要累加的写入结果，如分批推送时每一批的结果
+func (r *IngestResult) Add(other IngestResult) {
+	r.InsertLen += other.InsertLen
+	r.OverToken = append(r.OverToken, other.OverToken...)
+	r.Repeat = append(r.Repeat, other.Repeat...)
+	r.Error = append(r.Error, other.Error...)
+}
+
+// Summary 返回便于记录日志的结果摘要
+//
+// 返回值：
+//
+//	string: 如"插入42条，超出token 3条，重复1条，错误0条"
+func (r IngestResult) Summary() string {
+	return fmt.Sprintf("插入%d条，超出token %d条，重复%d条，错误%d条",
+		r.InsertLen, len(r.OverToken), len(r.Repeat), len(r.Error))
 }
 
+// SyncResult 目录同步结果模型
+//
+// 用于汇总SyncDirectory对各集合执行的操作数量。
+type SyncResult struct {
+	Created   int // 新建的集合数量
+	Updated   int // 内容变化后重建的集合数量
+	Deleted   int // 目录中已不存在而被删除的集合数量
+	Unchanged int // 内容未变化的集合数量
+}
+
+// Summary 返回便于记录日志的结果摘要
+//
+// 返回值：
+//
+//	string: 如"新建3个，更新1个，删除0个，未变化12个"
+func (r SyncResult) Summary() string {
+	return fmt.Sprintf("新建%d个，更新%d个，删除%d个，未变化%d个",
+		r.Created, r.Updated, r.Deleted, r.Unchanged)
+}
+
+// CollectionCreateResult 集合创建结果模型，与IngestResult相同
+type CollectionCreateResult = IngestResult
+
 // CollectionCreateResponse 集合创建响应模型
 //
 // 用于表示集合创建的响应。
@@ -223,30 +416,55 @@ type CollectionPermission struct {
 //
 // 用于表示集合的详细信息。
 type CollectionInfo struct {
-	ID             string               `json:"_id"`                      // 集合ID
-	ParentId       *string              `json:"parentId"`                 // 父级ID
-	TmbId          string               `json:"tmbId"`                    // 成员ID
-	Type           string               `json:"type"`                     // 集合类型
-	Name           string               `json:"name"`                     // 集合名称
-	UpdateTime     string               `json:"updateTime"`               // 更新时间
-	DataAmount     int                  `json:"dataAmount"`               // 数据量
-	TrainingAmount int                  `json:"trainingAmount"`           // 训练量
-	ExternalFileId string               `json:"externalFileId,omitempty"` // 外部文件ID
-	Tags           []string             `json:"tags,omitempty"`           // 标签
-	Forbid         bool                 `json:"forbid"`                   // 是否禁用
-	TrainingType   string               `json:"trainingType"`             // 训练类型
-	Permission     CollectionPermission `json:"permission"`               // 权限信息
-	RawLink        string               `json:"rawLink,omitempty"`        // 原始链接
-	DatasetId      interface{}          `json:"datasetId,omitempty"`      // 知识库ID
-	TeamId         string               `json:"teamId,omitempty"`         // 团队ID
-	RawTextLength  int                  `json:"rawTextLength,omitempty"`  // 原始文本长度
-	HashRawText    string               `json:"hashRawText,omitempty"`    // 原始文本哈希
-	CreateTime     string               `json:"createTime,omitempty"`     // 创建时间
-	CanWrite       bool                 `json:"canWrite,omitempty"`       // 是否可写
-	SourceName     string               `json:"sourceName,omitempty"`     // 来源名称
-	ChunkSize      int                  `json:"chunkSize,omitempty"`      // 分块大小
-	ChunkSplitter  string               `json:"chunkSplitter,omitempty"`  // 分块分割符
-	QAPrompt       string               `json:"qaPrompt,omitempty"`       // QA提示词
+	ID             string                 `json:"_id"`                      // 集合ID
+	ParentId       *string                `json:"parentId"`                 // 父级ID
+	TmbId          string                 `json:"tmbId"`                    // 成员ID
+	Type           CollectionType         `json:"type"`                     // 集合类型
+	Name           string                 `json:"name"`                     // 集合名称
+	UpdateTime     string                 `json:"updateTime"`               // 更新时间
+	DataAmount     int                    `json:"dataAmount"`               // 数据量
+	TrainingAmount int                    `json:"trainingAmount"`           // 训练量
+	ExternalFileId string                 `json:"externalFileId,omitempty"` // 外部文件ID
+	Tags           []string               `json:"tags,omitempty"`           // 标签
+	Forbid         bool                   `json:"forbid"`                   // 是否禁用
+	TrainingType   string                 `json:"trainingType"`             // 训练类型
+	Permission     CollectionPermission   `json:"permission"`               // 权限信息
+	RawLink        string                 `json:"rawLink,omitempty"`        // 原始链接
+	DatasetId      interface{}            `json:"datasetId,omitempty"`      // 知识库ID，可能是字符串或知识库对象，请使用DatasetIDString读取
+	TeamId         string                 `json:"teamId,omitempty"`         // 团队ID
+	RawTextLength  int                    `json:"rawTextLength,omitempty"`  // 原始文本长度
+	HashRawText    string                 `json:"hashRawText,omitempty"`    // 原始文本哈希
+	CreateTime     string                 `json:"createTime,omitempty"`     // 创建时间
+	CanWrite       bool                   `json:"canWrite,omitempty"`       // 是否可写
+	SourceName     string                 `json:"sourceName,omitempty"`     // 来源名称
+	ChunkSize      int                    `json:"chunkSize,omitempty"`      // 分块大小
+	ChunkSplitter  string                 `json:"chunkSplitter,omitempty"`  // 分块分割符
+	QAPrompt       string                 `json:"qaPrompt,omitempty"`       // QA提示词
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`       // 元数据，集合列表中不一定返回
+}
+
+// DatasetIDString 获取集合所属的知识库ID
+//
+// 接口返回的datasetId有两种形式：
+// - 字符串：直接为知识库ID，如"6593e137231a2be9c5603ba7"
+// - 对象：填充后的知识库信息，如{"_id":"6593e137231a2be9c5603ba7","name":"..."}
+//
+// 该方法兼容两种形式，无法识别时返回空字符串。
+func (c CollectionInfo) DatasetIDString() string {
+	switch v := c.DatasetId.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		id, _ := v["_id"].(string)
+		return id
+	case *DatasetInfo:
+		if v != nil {
+			return v.ID
+		}
+	case DatasetInfo:
+		return v.ID
+	}
+	return ""
 }
 
 // CollectionListRequest 集合列表请求模型
@@ -256,10 +474,34 @@ type CollectionListRequest struct {
 	Offset     int     `json:"offset"`               // 偏移量
 	PageSize   int     `json:"pageSize"`             // 每页数量，最大30
 	DatasetId  string  `json:"datasetId"`            // 知识库的ID(必填)
-	ParentId   *string `json:"parentId,omitempty"`   // 父级Id
+	ParentId   *string `json:"parentId,omitempty"`   // 父级Id，nil表示根目录
 	SearchText string  `json:"searchText,omitempty"` // 模糊搜索文本
+
+	// 以下排序字段不会发送到服务端，由SDK在获取当前页后在客户端排序，
+	// 因此只对当前页内的数据生效，不会改变分页本身的划分
+	SortField CollectionSortField `json:"-"` // 排序字段，为空时保持服务端默认顺序
+	SortOrder SortOrder           `json:"-"` // 排序方向，默认为降序
 }
 
+// CollectionSortField 集合列表排序字段
+type CollectionSortField string
+
+// 集合列表支持的排序字段
+const (
+	CollectionSortByCreateTime CollectionSortField = "createTime" // 按创建时间排序
+	CollectionSortByUpdateTime CollectionSortField = "updateTime" // 按更新时间排序
+	CollectionSortByDataAmount CollectionSortField = "dataAmount" // 按数据量排序
+)
+
+// SortOrder 排序方向
+type SortOrder string
+
+// 排序方向
+const (
+	SortDesc SortOrder = "desc" // 降序
+	SortAsc  SortOrder = "asc"  // 升序
+)
+
 // CollectionListResponse 集合列表响应模型
 //
 // 用于表示集合列表的响应。
@@ -275,10 +517,10 @@ type CollectionUpdateRequest struct {
 	ID             string   `json:"id,omitempty"`             // 集合的ID
 	DatasetId      string   `json:"datasetId,omitempty"`      // 知识库ID
 	ExternalFileId string   `json:"externalFileId,omitempty"` // 外部文件ID
-	ParentId       *string  `json:"parentId,omitempty"`       // 修改父级ID
+	ParentId       *string  `json:"parentId,omitempty"`       // 修改父级ID，nil表示不修改，指向空字符串表示移动到根目录
 	Name           string   `json:"name,omitempty"`           // 修改集合名称
 	Tags           []string `json:"tags,omitempty"`           // 修改集合标签
-	Forbid         bool     `json:"forbid,omitempty"`         // 修改集合禁用状态
+	Forbid         *bool    `json:"forbid,omitempty"`         // 修改集合禁用状态，nil表示不修改，指向false表示启用
 	CreateTime     string   `json:"createTime,omitempty"`     // 修改集合创建时间
 }
 
@@ -295,12 +537,58 @@ type CollectionDeleteRequest struct {
 //
 // 用于表示数据的向量索引。
 type Index struct {
-	Type   string `json:"type,omitempty"`   // 索引类型：default, custom, summary, question, image
+	Type   string `json:"type,omitempty"`   // 索引类型：default, custom, summary, question, image，可使用IndexType常量
 	DataId string `json:"dataId,omitempty"` // 关联的向量ID
 	Text   string `json:"text"`             // 文本内容
 	ID     string `json:"_id,omitempty"`    // 索引ID
 }
 
+// 索引类型
+const (
+	IndexTypeDefault  = "default"  // 默认索引，由系统根据数据内容自动生成
+	IndexTypeCustom   = "custom"   // 自定义索引
+	IndexTypeSummary  = "summary"  // 摘要索引
+	IndexTypeQuestion = "question" // 问题索引
+	IndexTypeImage    = "image"    // 图片索引
+)
+
+// CustomIndex 创建自定义索引
+func CustomIndex(text string) Index {
+	return Index{Type: IndexTypeCustom, Text: text}
+}
+
+// SummaryIndex 创建摘要索引
+func SummaryIndex(text string) Index {
+	return Index{Type: IndexTypeSummary, Text: text}
+}
+
+// QuestionIndex 创建问题索引
+func QuestionIndex(text string) Index {
+	return Index{Type: IndexTypeQuestion, Text: text}
+}
+
+// ValidateIndexes 校验索引类型是否合法
+//
+// 类型为空时由服务端按自定义索引处理，视为合法。
+//
+// 参数：
+//
+//	indexes: 需要校验的索引列表
+//
+// 返回值：
+//
+//	error: 存在未知索引类型时返回错误
+func ValidateIndexes(indexes []Index) error {
+	for i, idx := range indexes {
+		switch idx.Type {
+		case "", IndexTypeDefault, IndexTypeCustom, IndexTypeSummary, IndexTypeQuestion, IndexTypeImage:
+		default:
+			return fmt.Errorf("第%d个索引的类型 %q 无效", i+1, idx.Type)
+		}
+	}
+	return nil
+}
+
 // DatasetData 数据集数据模型
 //
 // 用于表示知识库中的数据。
@@ -322,6 +610,69 @@ type DatasetData struct {
 	CanWrite      bool    `json:"canWrite,omitempty"`      // 是否可写
 }
 
+// DataBudgetError 数据的索引文本超出向量模型token上限的错误
+type DataBudgetError struct {
+	Index    int // 超出上限的索引在Indexes中的位置，-1表示由Q和A生成的默认索引
+	Tokens   int // 估算的token数量
+	MaxToken int // 向量模型的最大token数
+}
+
+// Error 实现error接口
+func (e *DataBudgetError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("默认索引（q+a）估算约 %d tokens，超过向量模型上限 %d", e.Tokens, e.MaxToken)
+	}
+	return fmt.Sprintf("indexes[%d] 估算约 %d tokens，超过向量模型上限 %d", e.Index, e.Tokens, e.MaxToken)
+}
+
+// CheckDataBudget 在本地检查数据的索引文本是否可能超出向量模型的token上限
+//
+// 推送后才发现OverToken需要多一次往返，导入工具可以先用该函数筛出过长的数据，拆分后再推送。
+// 默认索引按FastGPT的规则由Q和A（A不为空时用换行连接）生成，自定义索引逐条检查。
+// token数量由DefaultTokenizer估算，与服务端的实际分词结果存在偏差，
+// 建议为上限留出一定余量，例如传入MaxToken的90%。
+//
+// 参数：
+//
+//	d: 待推送的数据
+//	vectorModelMaxToken: 向量模型的最大token数，可从GetDatasetDetail返回的VectorModel.MaxToken获取，小于等于0时不检查
+//
+// 返回值：
+//
+//	error: 所有超出上限的索引合并后的错误，可通过errors.As获取*DataBudgetError；全部未超出时返回nil
+//
+// 使用示例：
+//
+//	info, _ := datasetAPI.GetDatasetDetail(&model.DatasetDetailRequest{Id: datasetId})
+//	limit := info.VectorModel.MaxToken * 9 / 10
+//	for _, d := range data {
+//	    if err := model.CheckDataBudget(d, limit); err != nil {
+//	        log.Printf("数据过长，需要拆分: %v", err)
+//	    }
+//	}
+func CheckDataBudget(d DatasetData, vectorModelMaxToken int) error {
+	if vectorModelMaxToken <= 0 {
+		return nil
+	}
+
+	var errs []error
+	check := func(index int, text string) {
+		if tokens := DefaultTokenizer.CountTokens(text); tokens > vectorModelMaxToken {
+			errs = append(errs, &DataBudgetError{Index: index, Tokens: tokens, MaxToken: vectorModelMaxToken})
+		}
+	}
+
+	defaultText := d.Q
+	if d.A != "" {
+		defaultText = strings.TrimSpace(d.Q + "\n" + d.A)
+	}
+	check(-1, defaultText)
+	for i, index := range d.Indexes {
+		check(i, index.Text)
+	}
+	return errors.Join(errs...)
+}
+
 // DataPushRequest 数据推送请求模型
 //
 // 用于请求为集合批量添加数据。
@@ -333,15 +684,8 @@ type DataPushRequest struct {
 	Data         []DatasetData `json:"data"`             // 具体数据
 }
 
-// DataPushResponse 数据推送响应模型
-//
-// 用于表示数据推送的响应。
-type DataPushResponse struct {
-	InsertLen int      `json:"insertLen"` // 最终插入成功的数量
-	OverToken []string `json:"overToken"` // 超出token的
-	Repeat    []string `json:"repeat"`    // 重复的数量
-	Error     []string `json:"error"`     // 其他错误
-}
+// DataPushResponse 数据推送响应模型，与IngestResult相同
+type DataPushResponse = IngestResult
 
 // DataListRequest 数据列表请求模型
 //
@@ -402,6 +746,52 @@ type DatasetSearchTestRequest struct {
 	DatasetSearchExtensionBg         string  `json:"datasetSearchExtensionBg,omitempty"`         // 问题优化背景描述
 }
 
+// SearchConfig 知识库的默认检索参数
+//
+// FastGPT不在知识库上保存检索参数，每次搜索都需要在请求中指定。
+// 该结构体用于在客户端为知识库集中配置默认值，通过ApplyTo填充到搜索请求中。
+type SearchConfig struct {
+	Limit               int     // 最大tokens数量
+	Similarity          float64 // 最低相关度（0~1）
+	SearchMode          string  // 搜索模式：embedding | fullTextRecall | mixedRecall
+	UsingReRank         bool    // 使用重排
+	UsingExtensionQuery bool    // 使用问题优化
+	ExtensionModel      string  // 问题优化模型
+	ExtensionBackground string  // 问题优化背景描述
+}
+
+// ApplyTo 将默认检索参数填充到搜索请求中为零值的字段
+//
+// 请求中已设置的字段保持不变。布尔字段无法区分未设置和false，
+// 配置为true时会覆盖请求中的false。
+//
+// 参数：
+//
+//	req: 需要填充的搜索请求
+func (c SearchConfig) ApplyTo(req *DatasetSearchTestRequest) {
+	if req.Limit == 0 {
+		req.Limit = c.Limit
+	}
+	if req.Similarity == 0 {
+		req.Similarity = c.Similarity
+	}
+	if req.SearchMode == "" {
+		req.SearchMode = c.SearchMode
+	}
+	if c.UsingReRank {
+		req.UsingReRank = true
+	}
+	if c.UsingExtensionQuery {
+		req.DatasetSearchUsingExtensionQuery = true
+	}
+	if req.DatasetSearchExtensionModel == "" {
+		req.DatasetSearchExtensionModel = c.ExtensionModel
+	}
+	if req.DatasetSearchExtensionBg == "" {
+		req.DatasetSearchExtensionBg = c.ExtensionBackground
+	}
+}
+
 // DatasetSearchTestResult 搜索测试结果模型
 //
 // 用于表示搜索测试的结果。
@@ -414,4 +804,182 @@ type DatasetSearchTestResult struct {
 	SourceName   string  `json:"sourceName"`   // 来源名称
 	SourceId     string  `json:"sourceId"`     // 来源ID
 	Score        float64 `json:"score"`        // 相似度分数
+	ChunkIndex   int     `json:"chunkIndex"`   // 分块索引，表示数据在原文档中的位置
+
+	Collection *CollectionInfo `json:"-"` // 所属集合信息，仅在SearchTest使用WithCollectionInfo时填充
+}
+
+// SearchTestResults 搜索测试结果列表
+//
+// 与SearchTest返回的[]DatasetSearchTestResult底层类型相同，可直接转换后使用以下统计方法，
+// 用于调参时了解命中结果在各文档之间的分布。
+//
+// 使用示例：
+//
+//	results, _ := datasetAPI.SearchTest(req)
+//	for _, c := range model.SearchTestResults(results).TopCollections(5) {
+//	    fmt.Printf("%s: 命中%d条，最高分%.3f\n", c.SourceName, c.Count, c.BestScore)
+//	}
+type SearchTestResults []DatasetSearchTestResult
+
+// EstimateTokens 使用DefaultTokenizer估算结果内容（Q+A）的token数量
+//
+// 搜索接口按token数量截断结果，可用该值判断Limit是否设置得过小。
+//
+// 返回值：
+//
+//	int: 所有结果的Q和A的估算token数之和
+func (r SearchTestResults) EstimateTokens() int {
+	total := 0
+	for _, result := range r {
+		total += DefaultTokenizer.CountTokens(result.Q) + DefaultTokenizer.CountTokens(result.A)
+	}
+	return total
+}
+
+// CollectionScore 单个集合的命中统计
+type CollectionScore struct {
+	CollectionId string  // 集合ID
+	SourceName   string  // 来源名称，取该集合第一条结果的来源名称
+	Count        int     // 命中的结果数量
+	BestScore    float64 // 最高相似度分数
+	AverageScore float64 // 平均相似度分数
+}
+
+// GroupByCollection 按集合ID对结果分组
+//
+// 返回值：
+//
+//	map[string][]DatasetSearchTestResult: 以集合ID为键的结果，组内保持原有顺序
+func (r SearchTestResults) GroupByCollection() map[string][]DatasetSearchTestResult {
+	groups := make(map[string][]DatasetSearchTestResult)
+	for _, result := range r {
+		groups[result.CollectionId] = append(groups[result.CollectionId], result)
+	}
+	return groups
+}
+
+// TopCollections 返回最高分排名前n的集合
+//
+// 按最高分降序排列，最高分相同时按平均分降序，再相同时按集合ID升序，保证结果稳定。
+//
+// 参数：
+//
+//	n: 返回的集合数量，小于等于0或超过集合总数时返回全部集合
+//
+// 返回值：
+//
+//	[]CollectionScore: 排序后的集合命中统计
+func (r SearchTestResults) TopCollections(n int) []CollectionScore {
+	var scores []CollectionScore
+	for collectionId, results := range r.GroupByCollection() {
+		score := CollectionScore{
+			CollectionId: collectionId,
+			SourceName:   results[0].SourceName,
+			Count:        len(results),
+			BestScore:    results[0].Score,
+		}
+		var total float64
+		for _, result := range results {
+			total += result.Score
+			if result.Score > score.BestScore {
+				score.BestScore = result.Score
+			}
+		}
+		score.AverageScore = total / float64(len(results))
+		scores = append(scores, score)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].BestScore != scores[j].BestScore {
+			return scores[i].BestScore > scores[j].BestScore
+		}
+		if scores[i].AverageScore != scores[j].AverageScore {
+			return scores[i].AverageScore > scores[j].AverageScore
+		}
+		return scores[i].CollectionId < scores[j].CollectionId
+	})
+
+	if n > 0 && n < len(scores) {
+		scores = scores[:n]
+	}
+	return scores
+}
+
+// 搜索模式
+const (
+	SearchModeEmbedding      = "embedding"      // 语义检索
+	SearchModeFullTextRecall = "fullTextRecall" // 全文检索
+	SearchModeMixedRecall    = "mixedRecall"    // 混合检索
+)
+
+// defaultSearchTestLimit 搜索测试默认的最大tokens数量
+const defaultSearchTestLimit = 1500
+
+// NewSearchTest 创建带推荐默认值的搜索测试请求
+//
+// 默认使用语义检索（embedding）、最大1500 tokens、最低相关度0、不使用重排，
+// 可以通过WithRerank、WithMode、WithExtensionQuery链式调整。
+// 返回的仍是普通的DatasetSearchTestRequest，也可以直接修改字段。
+//
+// 参数：
+//
+//	datasetId: 知识库ID
+//	text: 需要测试的文本
+//
+// 返回值：
+//
+//	*DatasetSearchTestRequest: 搜索测试请求
+//
+// 使用示例：
+//
+//	req := model.NewSearchTest("your-dataset-id", "FastGPT是什么？").
+//	    WithMode(model.SearchModeMixedRecall).
+//	    WithRerank()
+//	results, err := fgpt.Dataset.SearchTest(req)
+func NewSearchTest(datasetId, text string) *DatasetSearchTestRequest {
+	return &DatasetSearchTestRequest{
+		DatasetId:  datasetId,
+		Text:       text,
+		Limit:      defaultSearchTestLimit,
+		Similarity: 0,
+		SearchMode: SearchModeEmbedding,
+	}
+}
+
+// WithRerank 开启结果重排
+func (r *DatasetSearchTestRequest) WithRerank() *DatasetSearchTestRequest {
+	r.UsingReRank = true
+	return r
+}
+
+// WithMode 设置搜索模式，可选值：SearchModeEmbedding、SearchModeFullTextRecall、SearchModeMixedRecall
+func (r *DatasetSearchTestRequest) WithMode(mode string) *DatasetSearchTestRequest {
+	r.SearchMode = mode
+	return r
+}
+
+// WithLimit 设置最大tokens数量
+func (r *DatasetSearchTestRequest) WithLimit(limit int) *DatasetSearchTestRequest {
+	r.Limit = limit
+	return r
+}
+
+// WithSimilarity 设置最低相关度（0~1）
+func (r *DatasetSearchTestRequest) WithSimilarity(similarity float64) *DatasetSearchTestRequest {
+	r.Similarity = similarity
+	return r
+}
+
+// WithExtensionQuery 开启问题优化
+//
+// 参数：
+//
+//	model: 问题优化使用的模型
+//	bg: 问题优化的背景描述
+func (r *DatasetSearchTestRequest) WithExtensionQuery(model, bg string) *DatasetSearchTestRequest {
+	r.DatasetSearchUsingExtensionQuery = true
+	r.DatasetSearchExtensionModel = model
+	r.DatasetSearchExtensionBg = bg
+	return r
 }
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// searchResults 混合了三个集合的检索结果
+var searchResults = SearchTestResults{
+	{Q: "q1", CollectionId: "c1", SourceName: "手册.pdf", Score: 0.6},
+	{Q: "q2", CollectionId: "c2", SourceName: "FAQ.md", Score: 0.9},
+	{Q: "q3", CollectionId: "c1", SourceName: "手册.pdf", Score: 0.8},
+	{Q: "q4", CollectionId: "c3", SourceName: "公告.txt", Score: 0.8},
+	{Q: "q5", CollectionId: "c2", SourceName: "FAQ.md", Score: 0.5},
+}
+
+func TestGroupByCollection(t *testing.T) {
+	groups := searchResults.GroupByCollection()
+	if len(groups) != 3 {
+		t.Fatalf("GroupByCollection() got %d groups, want 3", len(groups))
+	}
+
+	var qs []string
+	for _, result := range groups["c1"] {
+		qs = append(qs, result.Q)
+	}
+	if want := []string{"q1", "q3"}; !reflect.DeepEqual(qs, want) {
+		t.Errorf("group c1 = %v, want %v", qs, want)
+	}
+}
+
+func TestTopCollections(t *testing.T) {
+	c2 := CollectionScore{CollectionId: "c2", SourceName: "FAQ.md", Count: 2, BestScore: 0.9, AverageScore: 0.7}
+	c1 := CollectionScore{CollectionId: "c1", SourceName: "手册.pdf", Count: 2, BestScore: 0.8, AverageScore: 0.7}
+	c3 := CollectionScore{CollectionId: "c3", SourceName: "公告.txt", Count: 1, BestScore: 0.8, AverageScore: 0.8}
+
+	tests := []struct {
+		name string
+		n    int
+		want []CollectionScore
+	}{
+		{name: "前两名", n: 2, want: []CollectionScore{c2, c3}},
+		{name: "最高分相同时按平均分排序", n: 3, want: []CollectionScore{c2, c3, c1}},
+		{name: "n超过集合数量时返回全部", n: 10, want: []CollectionScore{c2, c3, c1}},
+		{name: "n为0时返回全部", n: 0, want: []CollectionScore{c2, c3, c1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchResults.TopCollections(tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("TopCollections(%d) got %d collections, want %d", tt.n, len(got), len(tt.want))
+			}
+			for i := range got {
+				// 平均分是浮点数运算结果，只比较到小数点后6位
+				if got[i].CollectionId != tt.want[i].CollectionId || got[i].Count != tt.want[i].Count ||
+					got[i].SourceName != tt.want[i].SourceName || got[i].BestScore != tt.want[i].BestScore ||
+					math.Abs(got[i].AverageScore-tt.want[i].AverageScore) > 1e-6 {
+					t.Errorf("TopCollections(%d)[%d] = %+v, want %+v", tt.n, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DedupOption 数据去重的比较方式
+type DedupOption func(*dedupOptions)
+
+// dedupOptions 数据去重的配置
+type dedupOptions struct {
+	includeA   bool // 是否同时比较A
+	ignoreCase bool // 是否忽略大小写
+	trimSpace  bool // 是否忽略首尾空白
+}
+
+// WithDedupIncludeA 按Q+A判断重复，默认只比较Q
+func WithDedupIncludeA() DedupOption {
+	return func(o *dedupOptions) {
+		o.includeA = true
+	}
+}
+
+// WithDedupIgnoreCase 比较时忽略大小写
+func WithDedupIgnoreCase() DedupOption {
+	return func(o *dedupOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// WithDedupTrimSpace 比较时忽略首尾空白字符
+func WithDedupTrimSpace() DedupOption {
+	return func(o *dedupOptions) {
+		o.trimSpace = true
+	}
+}
+
+// DedupData 去除重复的数据
+//
+// 在PushData之前合并本地重复的数据，减少服务端去重的开销以及响应中的Repeat数量。
+// 重复的数据只保留第一次出现的那一条，其余数据保持原有顺序。
+//
+// 参数：
+//
+//	data: 待推送的数据
+//	opts: 比较方式，如WithDedupIncludeA、WithDedupIgnoreCase、WithDedupTrimSpace
+//
+// 返回值：
+//
+//	[]DatasetData: 去重后的数据，不会修改传入的切片
+//
+// 使用示例：
+//
+//	data = model.DedupData(data, model.WithDedupTrimSpace(), model.WithDedupIgnoreCase())
+func DedupData(data []DatasetData, opts ...DedupOption) []DatasetData {
+	var options dedupOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	normalize := func(s string) string {
+		if options.trimSpace {
+			s = strings.TrimSpace(s)
+		}
+		if options.ignoreCase {
+			s = strings.ToLower(s)
+		}
+		return s
+	}
+
+	seen := make(map[string]struct{}, len(data))
+	result := make([]DatasetData, 0, len(data))
+	for _, d := range data {
+		key := normalize(d.Q)
+		if options.includeA {
+			key += "\x00" + normalize(d.A) // 使用不会出现在文本中的分隔符，避免拼接歧义
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, d)
+	}
+	return result
+}
+
+// SplitOversizedData 按字符数拆分出可能超出token限制的数据
+//
+// 服务端会将超出向量模型token上限的数据放入OverToken，该方法按Q+A的字符数在本地提前筛选，
+// 便于在推送前截断或拆分过长的数据。字符数只是token数的近似，阈值应按所用模型适当保守设置。
+//
+// 参数：
+//
+//	data: 待推送的数据
+//	maxChars: Q与A的最大字符数之和，小于等于0时不做限制
+//
+// 返回值：
+//
+//	ok: 未超出限制的数据
+//	oversized: 超出限制的数据
+//
+// 使用示例：
+//
+//	ok, oversized := model.SplitOversizedData(data, 3000)
+//	for _, d := range oversized {
+//	    log.Printf("数据过长: %.20s", d.Q)
+//	}
+func SplitOversizedData(data []DatasetData, maxChars int) (ok, oversized []DatasetData) {
+	for _, d := range data {
+		if maxChars > 0 && utf8.RuneCountInString(d.Q)+utf8.RuneCountInString(d.A) > maxChars {
+			oversized = append(oversized, d)
+			continue
+		}
+		ok = append(ok, d)
+	}
+	return ok, oversized
+}
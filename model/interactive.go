@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 交互节点类型
+const (
+	InteractiveUserSelect = "userSelect" // 用户选择
+	InteractiveUserInput  = "userInput"  // 表单输入
+)
+
+// UserSelectParams 将userSelect交互节点的参数解析为UserSelectParams
+//
+// 返回值：
+//
+//	*UserSelectParams: 用户选择参数
+//	error: 交互类型不是userSelect或参数格式不正确时返回错误
+func (i Interactive) UserSelectParams() (*UserSelectParams, error) {
+	var params UserSelectParams
+	if err := i.decodeParams(InteractiveUserSelect, &params); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// UserInputParams 将userInput交互节点的参数解析为UserInputParams
+//
+// 返回值：
+//
+//	*UserInputParams: 表单输入参数
+//	error: 交互类型不是userInput或参数格式不正确时返回错误
+func (i Interactive) UserInputParams() (*UserInputParams, error) {
+	var params UserInputParams
+	if err := i.decodeParams(InteractiveUserInput, &params); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// decodeParams 检查交互类型并将Params重新解析到v
+func (i Interactive) decodeParams(interactiveType string, v interface{}) error {
+	if i.Type != interactiveType {
+		return fmt.Errorf("交互类型为 %s，不是 %s", i.Type, interactiveType)
+	}
+	data, err := json.Marshal(i.Params)
+	if err != nil {
+		return fmt.Errorf("解析交互参数失败: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("解析交互参数失败: %w", err)
+	}
+	return nil
+}
+
+// BuildUserSelectResponse 构建用户选择后继续运行工作流的对话请求
+//
+// FastGPT通过同一对话中的下一条用户消息恢复交互节点，userSelect节点的消息内容为所选选项的值。
+//
+// 参数：
+//
+//	chatId: 交互节点所在的对话ID
+//	params: 交互节点的选择参数，通过Interactive.UserSelectParams获取
+//	value: 用户选择的选项值
+//
+// 返回值：
+//
+//	*ChatRequest: 已设置ChatId、Stream、Detail和消息的对话请求
+//	error: 选项值不在可选项中时返回错误
+//
+// 使用示例：
+//
+//	params, _ := interactive.UserSelectParams()
+//	req, err := model.BuildUserSelectResponse(chatId, *params, params.UserSelectOptions[0].Value)
+func BuildUserSelectResponse(chatId string, params UserSelectParams, value string) (*ChatRequest, error) {
+	var values []string
+	for _, option := range params.UserSelectOptions {
+		if option.Value == value {
+			return newInteractiveResponse(chatId, value), nil
+		}
+		values = append(values, option.Value)
+	}
+	return nil, fmt.Errorf("选项 %q 不在可选项 %v 中", value, values)
+}
+
+// BuildInputFormResponse 构建提交表单后继续运行工作流的对话请求
+//
+// 按表单项定义校验必填项、值类型和下拉选项（规则与ValidateVariables相同），
+// 未提供的字段使用表单项的默认值，然后将表单值序列化为JSON作为下一条用户消息的内容，
+// 与FastGPT页面提交表单时的格式一致。
+//
+// 参数：
+//
+//	chatId: 交互节点所在的对话ID
+//	params: 交互节点的表单参数，通过Interactive.UserInputParams获取
+//	values: 用户填写的表单值，键为InputFormItem.Key
+//
+// 返回值：
+//
+//	*ChatRequest: 已设置ChatId、Stream、Detail和消息的对话请求
+//	error: 所有校验失败项合并后的错误，如缺少必填字段
+//
+// 使用示例：
+//
+//	params, err := interactive.UserInputParams()
+//	if err != nil {
+//	    return err
+//	}
+//	req, err := model.BuildInputFormResponse(chatId, *params, map[string]interface{}{
+//	    "name": "张三",
+//	    "age":  18,
+//	})
+//	if err != nil {
+//	    return err // 如：变量 name(姓名) 为必填项
+//	}
+//	err = chatAPI.Chat(req, handler)
+func BuildInputFormResponse(chatId string, params UserInputParams, values map[string]interface{}) (*ChatRequest, error) {
+	defs := make([]VariableDef, 0, len(params.InputForm))
+	submitted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		submitted[key] = value
+	}
+	for _, item := range params.InputForm {
+		defs = append(defs, VariableDef{
+			Key:          item.Key,
+			Label:        item.Label,
+			Type:         item.Type,
+			Required:     item.Required,
+			ValueType:    item.ValueType,
+			DefaultValue: item.DefaultValue,
+			List:         item.List,
+		})
+		if value, ok := submitted[item.Key]; (!ok || value == nil || value == "") && item.DefaultValue != nil {
+			submitted[item.Key] = item.DefaultValue
+		}
+	}
+	if err := ValidateVariables(defs, submitted); err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(submitted)
+	if err != nil {
+		return nil, fmt.Errorf("序列化表单值失败: %w", err)
+	}
+	return newInteractiveResponse(chatId, string(content)), nil
+}
+
+// newInteractiveResponse 创建恢复交互节点的对话请求
+func newInteractiveResponse(chatId, content string) *ChatRequest {
+	return &ChatRequest{
+		ChatId: chatId,
+		Stream: true,
+		Detail: true,
+		Messages: []Message{
+			{Role: "user", Content: TextContent(content)},
+		},
+	}
+}
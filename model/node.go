@@ -0,0 +1,143 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 常用的工作流节点类型，对应ResponseDataItem.ModuleType
+const (
+	NodeTypeHTTPRequest  = "httpRequest468" // HTTP请求节点
+	NodeTypeCode         = "code"           // 代码运行节点
+	NodeTypePluginModule = "pluginModule"   // 插件节点
+)
+
+// HTTPNodeOutput HTTP请求节点的运行详情
+type HTTPNodeOutput struct {
+	Params     map[string]interface{} `json:"params,omitempty"`     // 请求的查询参数
+	Body       interface{}            `json:"body,omitempty"`       // 请求体
+	Headers    map[string]interface{} `json:"headers,omitempty"`    // 请求头
+	HTTPResult interface{}            `json:"httpResult,omitempty"` // 接口返回结果
+}
+
+// CodeNodeOutput 代码运行节点的运行详情
+type CodeNodeOutput struct {
+	CodeLog       string                 `json:"codeLog,omitempty"`       // 代码运行日志
+	CustomInputs  map[string]interface{} `json:"customInputs,omitempty"`  // 代码的输入参数
+	CustomOutputs map[string]interface{} `json:"customOutputs,omitempty"` // 代码的返回值
+}
+
+// PluginNodeOutput 插件节点的运行详情
+type PluginNodeOutput struct {
+	PluginOutput map[string]interface{} `json:"pluginOutput,omitempty"` // 插件输出
+	PluginDetail []ResponseDataItem     `json:"pluginDetail,omitempty"` // 插件内部各节点的运行详情
+}
+
+// nodeOutputFields 各节点类型中表示节点输出的字段，NodeOutput优先返回该字段
+var nodeOutputFields = map[string]string{
+	NodeTypeHTTPRequest:  "httpResult",
+	NodeTypeCode:         "customOutputs",
+	NodeTypePluginModule: "pluginOutput",
+}
+
+// UnmarshalJSON 解析响应数据项并保留原始JSON，供As和NodeOutput读取未建模的字段
+func (r *ResponseDataItem) UnmarshalJSON(data []byte) error {
+	type alias ResponseDataItem
+	var item alias
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	*r = ResponseDataItem(item)
+	r.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// rawJSON 返回响应数据项的原始JSON，手动构造的数据项没有原始JSON时重新序列化
+func (r ResponseDataItem) rawJSON() (json.RawMessage, error) {
+	if len(r.raw) > 0 {
+		return r.raw, nil
+	}
+	return json.Marshal(r)
+}
+
+// As 将节点的运行详情解码到v
+//
+// ResponseDataItem只建模了各节点共有的字段，HTTP请求、代码运行、插件等节点特有的字段
+// 可以通过该方法解码到HTTPNodeOutput、CodeNodeOutput、PluginNodeOutput或自定义结构体。
+//
+// 参数：
+//
+//	nodeType: 期望的节点类型，如NodeTypeHTTPRequest，为空表示不检查节点类型
+//	v: 目标结构体指针
+//
+// 返回值：
+//
+//	error: 节点类型不符或解码失败时返回错误
+//
+// 使用示例：
+//
+//	var out model.HTTPNodeOutput
+//	if err := item.As(model.NodeTypeHTTPRequest, &out); err == nil {
+//	    fmt.Println(out.HTTPResult)
+//	}
+func (r ResponseDataItem) As(nodeType string, v interface{}) error {
+	if nodeType != "" && r.ModuleType != nodeType {
+		return fmt.Errorf("节点 %s 的类型为 %s，不是 %s", r.ModuleName, r.ModuleType, nodeType)
+	}
+
+	data, err := r.rawJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// NodeOutput 获取指定名称节点的输出
+//
+// HTTP请求节点返回httpResult，代码运行节点返回customOutputs，插件节点返回pluginOutput，
+// 其他类型的节点返回完整的运行详情。存在多个同名节点时返回第一个。
+//
+// 参数：
+//
+//	moduleName: 节点名称，即工作流中节点的显示名称
+//
+// 返回值：
+//
+//	json.RawMessage: 节点输出的原始JSON
+//	error: 找不到节点或读取失败时返回错误
+//
+// 使用示例：
+//
+//	out, err := resp.NodeOutput("查询天气")
+//	if err == nil {
+//	    var weather struct {
+//	        Temp float64 `json:"temp"`
+//	    }
+//	    json.Unmarshal(out, &weather)
+//	}
+func (r *ChatDetailResponse) NodeOutput(moduleName string) (json.RawMessage, error) {
+	for _, item := range r.ResponseData {
+		if item.ModuleName != moduleName {
+			continue
+		}
+
+		data, err := item.rawJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok := nodeOutputFields[item.ModuleType]
+		if !ok {
+			return data, nil
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		if out, ok := fields[field]; ok {
+			return out, nil
+		}
+		return nil, fmt.Errorf("节点 %s 没有输出 %s", moduleName, field)
+	}
+	return nil, fmt.Errorf("响应中没有名为 %s 的节点", moduleName)
+}
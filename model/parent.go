@@ -0,0 +1,57 @@
+package model
+
+// RootParent 返回表示根目录的ParentId，即nil
+//
+// 知识库、集合等请求中的ParentId为*string类型：nil表示根目录，请求中省略该字段；
+// 指向目录ID表示放在该目录下。不要使用指向空字符串的指针（如parentId := ""; req.ParentId = &parentId），
+// 服务端可能原样保存空字符串，使数据落在列表中看不到的"幽灵根目录"。
+// DatasetAPI的创建和列表方法会将指向空字符串的ParentId按nil处理；
+// CollectionUpdateRequest中nil表示不修改父级，见其字段说明。
+//
+// 返回值：
+//
+//	*string: nil
+//
+// 使用示例：
+//
+//	req := &model.DatasetCreateRequest{ParentId: model.RootParent(), Name: "产品手册"}
+func RootParent() *string {
+	return nil
+}
+
+// Parent 返回指向目录id的ParentId，id为空时返回nil表示根目录
+//
+// 推荐使用Parent而不是直接取字符串变量的地址，目录ID可能为空（如EnsureFolderPath在path为空时返回空字符串），
+// 此时得到nil而不是指向空字符串的指针，语义见RootParent。
+//
+// 参数：
+//
+//	id: 目录ID，为空表示根目录
+//
+// 返回值：
+//
+//	*string: 指向id的指针，id为空时为nil
+//
+// 使用示例：
+//
+//	folderId, _ := datasetAPI.EnsureFolderPath(path) // path为空时folderId为空字符串
+//	req := &model.DatasetCreateRequest{ParentId: model.Parent(folderId), Name: "产品手册"}
+func Parent(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// IsRootParent 判断ParentId是否表示根目录（nil或指向空字符串）
+//
+// 参数：
+//
+//	parentId: 请求或集合信息中的ParentId
+//
+// 返回值：
+//
+//	bool: 是否为根目录
+func IsRootParent(parentId *string) bool {
+	return parentId == nil || *parentId == ""
+}
@@ -0,0 +1,36 @@
+package model
+
+import "testing"
+
+func TestParent(t *testing.T) {
+	if RootParent() != nil {
+		t.Errorf("RootParent() = %v, want nil", RootParent())
+	}
+	if got := Parent(""); got != nil {
+		t.Errorf("Parent(\"\") = %q, want nil", *got)
+	}
+	if got := Parent("f1"); got == nil || *got != "f1" {
+		t.Errorf("Parent(\"f1\") = %v, want pointer to f1", got)
+	}
+}
+
+func TestIsRootParent(t *testing.T) {
+	empty, folder := "", "f1"
+	tests := []struct {
+		name     string
+		parentId *string
+		want     bool
+	}{
+		{name: "nil", parentId: nil, want: true},
+		{name: "指向空字符串", parentId: &empty, want: true},
+		{name: "指向目录ID", parentId: &folder, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRootParent(tt.parentId); got != tt.want {
+				t.Errorf("IsRootParent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PluginInput 插件应用的输入参数定义模型
+//
+// 对应ChatAppInfo.PluginInputs中的每一项，描述插件应用需要的输入参数。
+type PluginInput struct {
+	Key            string      `json:"key"`                      // 参数键，对应ChatRequest.Variables中的键
+	Label          string      `json:"label,omitempty"`          // 参数显示名称
+	Description    string      `json:"description,omitempty"`    // 参数描述
+	ValueType      string      `json:"valueType,omitempty"`      // 值类型：string, number, boolean, object, arrayString等
+	Required       bool        `json:"required,omitempty"`       // 是否必填
+	DefaultValue   interface{} `json:"defaultValue,omitempty"`   // 默认值
+	RenderTypeList []string    `json:"renderTypeList,omitempty"` // 输入框类型列表
+}
+
+// BuildPluginVariables 按插件输入定义构建对话变量
+//
+// 调用插件类型的应用时，插件输入通过ChatRequest.Variables传递。该函数为未提供的参数填充默认值，
+// 检查必填参数和基本值类型，并拒绝未在插件中定义的参数，避免拼写错误被静默忽略。
+//
+// 参数：
+//
+//	inputs: 插件输入定义，通常来自AppAPI.GetAppConfig返回的PluginInputs
+//	values: 调用方提供的参数值
+//
+// 返回值：
+//
+//	map[string]interface{}: 可直接赋值给ChatRequest.Variables的变量
+//	error: 所有校验失败项合并后的错误
+//
+// 使用示例：
+//
+//	appInfo, _ := fgpt.App.GetAppConfig("your-plugin-app-id")
+//	vars, err := model.BuildPluginVariables(appInfo.PluginInputs, map[string]interface{}{
+//	    "city": "杭州",
+//	})
+//	req.Variables = vars
+func BuildPluginVariables(inputs []PluginInput, values map[string]interface{}) (map[string]interface{}, error) {
+	var errs []error
+	variables := make(map[string]interface{}, len(inputs))
+	known := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		known[input.Key] = true
+
+		value, ok := values[input.Key]
+		if !ok || value == nil {
+			if input.DefaultValue != nil {
+				variables[input.Key] = input.DefaultValue
+			} else if input.Required {
+				errs = append(errs, fmt.Errorf("插件参数 %s(%s) 为必填项", input.Key, input.Label))
+			}
+			continue
+		}
+
+		// 复用变量校验中的基本类型检查
+		if err := validateVariable(VariableDef{Key: input.Key, ValueType: input.ValueType}, value); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		variables[input.Key] = value
+	}
+
+	for key := range values {
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("插件未定义参数 %s", key))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
+
+// PluginOutputAs 将插件输出解码到v
+//
+// 参数：
+//
+//	v: 目标结构体指针，字段通过JSON标签与插件输出的键对应
+//
+// 返回值：
+//
+//	error: 没有插件输出或解码失败时返回错误
+//
+// 使用示例：
+//
+//	var out struct {
+//	    Weather string `json:"weather"`
+//	}
+//	err := item.PluginOutputAs(&out)
+func (r ResponseDataItem) PluginOutputAs(v interface{}) error {
+	if r.PluginOutput == nil {
+		return fmt.Errorf("节点 %s 没有插件输出", r.ModuleName)
+	}
+	data, err := json.Marshal(r.PluginOutput)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,42 @@
+package model
+
+// 服务端版本类型
+const (
+	EditionCommunity  = "community"  // 社区版
+	EditionCommercial = "commercial" // 商业版
+)
+
+// ServerInfo 服务端信息模型
+//
+// 对应/api/common/system/getInitData接口返回的部分字段，用于判断服务端版本和功能开关。
+type ServerInfo struct {
+	SystemVersion string                 `json:"systemVersion"` // 系统版本号，如4.9.0
+	FeConfigs     map[string]interface{} `json:"feConfigs"`     // 前端配置，包含isPlus等功能开关
+}
+
+// IsPlus 判断服务端是否为商业版
+func (s ServerInfo) IsPlus() bool {
+	return s.Feature("isPlus")
+}
+
+// Edition 返回服务端版本类型，EditionCommunity或EditionCommercial
+func (s ServerInfo) Edition() string {
+	if s.IsPlus() {
+		return EditionCommercial
+	}
+	return EditionCommunity
+}
+
+// Feature 判断前端配置中的布尔功能开关是否开启
+//
+// 参数：
+//
+//	key: feConfigs中的字段名，如"isPlus"、"show_git"
+//
+// 返回值：
+//
+//	bool: 字段存在且为true时返回true
+func (s ServerInfo) Feature(key string) bool {
+	enabled, _ := s.FeConfigs[key].(bool)
+	return enabled
+}
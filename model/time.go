@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// TimeLayout FastGPT接口使用的时间格式，如"2024-01-01T00:00:00.000Z"
+const TimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// FormatTime 将时间格式化为FastGPT接口要求的UTC时间字符串
+//
+// 参数：
+//
+//	t: 任意时区的时间
+//
+// 返回值：
+//
+//	string: 如"2024-01-01T00:00:00.000Z"
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(TimeLayout)
+}
+
+// ParseTime 解析接口返回的时间字符串
+//
+// 兼容带毫秒和不带毫秒的ISO 8601格式。
+//
+// 参数：
+//
+//	s: 时间字符串，如UpdateTime、CreateTime字段的值
+//
+// 返回值：
+//
+//	time.Time: 解析后的时间
+//	error: 格式不正确时返回错误
+func ParseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// FillUpdateTime 为UpdateTime为空的数据填充更新时间
+//
+// 参数：
+//
+//	t: 填充的时间，通常为time.Now()
+func (r *DataPushRequest) FillUpdateTime(t time.Time) {
+	now := FormatTime(t)
+	for i := range r.Data {
+		if r.Data[i].UpdateTime == "" {
+			r.Data[i].UpdateTime = now
+		}
+	}
+}
+
+// FillCreateTime CreateTime为空时填充文件创建时间
+//
+// 参数：
+//
+//	t: 填充的时间，通常为文件的修改时间或time.Now()
+func (r *CollectionCreateExternalFileRequest) FillCreateTime(t time.Time) {
+	if r.CreateTime == "" {
+		r.CreateTime = FormatTime(t)
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import "unicode"
+
+// 消息token估算相关常量，参考OpenAI对话格式的计算方式
+const (
+	tokensPerMessage = 4  // 每条消息的格式开销（角色、分隔符等）
+	tokensPerReply   = 3  // 回复的起始开销
+	tokensPerImage   = 85 // 每张图片的估算token数（按低精度图片计算）
+)
+
+// Tokenizer 文本分词器接口
+//
+// 用于计算文本的token数量，可替换为精确的分词实现（如tiktoken）。
+type Tokenizer interface {
+	CountTokens(text string) int // 返回文本的token数量
+}
+
+// TokenizerFunc 函数形式的分词器，便于直接传入计数函数
+type TokenizerFunc func(text string) int
+
+// CountTokens 实现Tokenizer接口
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// DefaultTokenizer 默认分词器，EstimateTokens使用该分词器进行估算
+//
+// 默认实现为近似算法：中日韩字符按每字1个token计算，其他字符按每4个字符1个token计算，
+// 与cl100k编码的结果大致相当。需要精确计数时可替换为自定义实现。
+var DefaultTokenizer Tokenizer = TokenizerFunc(approximateTokens)
+
+// EstimateTokens 使用DefaultTokenizer估算消息列表的token数量
+//
+// 支持字符串内容和结构化内容。
+// 结果为近似值，适用于在发送对话前截断历史消息，避免超出模型上下文限制。
+//
+// 参数：
+//
+//	messages: 消息列表
+//
+// 返回值：
+//
+//	int: 估算的token数量
+//
+// 使用示例：
+//
+//	if model.EstimateTokens(messages) > 8000 {
+//	    messages = messages[len(messages)-10:]
+//	}
+func EstimateTokens(messages []Message) int {
+	return EstimateTokensWith(DefaultTokenizer, messages)
+}
+
+// EstimateTokensWith 使用指定的分词器估算消息列表的token数量
+//
+// 参数：
+//
+//	tokenizer: 分词器，为nil时使用DefaultTokenizer
+//	messages: 消息列表
+//
+// 返回值：
+//
+//	int: 估算的token数量
+func EstimateTokensWith(tokenizer Tokenizer, messages []Message) int {
+	if tokenizer == nil {
+		tokenizer = DefaultTokenizer
+	}
+	if len(messages) == 0 {
+		return 0
+	}
+
+	total := tokensPerReply
+	for _, msg := range messages {
+		total += tokensPerMessage
+		total += tokenizer.CountTokens(msg.Role)
+		total += contentTokens(tokenizer, msg.Content)
+	}
+	return total
+}
+
+// contentTokens 计算消息内容的token数量
+func contentTokens(tokenizer Tokenizer, content MessageContent) int {
+	if content.IsText {
+		return tokenizer.CountTokens(content.Text)
+	}
+
+	total := 0
+	for _, item := range content.Items {
+		total += contentItemTokens(tokenizer, item)
+	}
+	return total
+}
+
+// contentItemTokens 计算单个结构化内容项的token数量
+func contentItemTokens(tokenizer Tokenizer, item ContentItem) int {
+	switch item.Type {
+	case "image_url":
+		return tokensPerImage
+	case "file_url":
+		// 文件内容由服务端解析，这里只计算文件名
+		if item.FileURL != nil {
+			return tokenizer.CountTokens(item.FileURL.Name)
+		}
+		return 0
+	default:
+		return tokenizer.CountTokens(item.Text)
+	}
+}
+
+// approximateTokens 近似计算文本的token数量
+func approximateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	cjk, other := 0, 0
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+			unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+
+	// 其他字符按每4个字符1个token向上取整
+	return cjk + (other+3)/4
+}
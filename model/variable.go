@@ -0,0 +1,295 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// VariableDef 应用全局变量定义模型
+//
+// 用于表示应用对话配置中声明的全局变量，对应ChatConfig.Variables中的每一项。
+type VariableDef struct {
+	Id           string         `json:"id,omitempty"`           // 变量ID
+	Key          string         `json:"key"`                    // 变量键，对应ChatRequest.Variables中的键
+	Label        string         `json:"label"`                  // 变量显示名称
+	Type         string         `json:"type"`                   // 输入类型：input, textarea, numberInput, select, switch, custom等
+	Description  string         `json:"description,omitempty"`  // 变量描述
+	Required     bool           `json:"required"`               // 是否必填
+	ValueType    string         `json:"valueType,omitempty"`    // 值类型：string, number, boolean, object等
+	DefaultValue interface{}    `json:"defaultValue,omitempty"` // 默认值
+	MaxLen       int            `json:"maxLen,omitempty"`       // 文本最大长度，0表示不限制
+	Min          *float64       `json:"min,omitempty"`          // 数字最小值
+	Max          *float64       `json:"max,omitempty"`          // 数字最大值
+	Enums        []VariableEnum `json:"enums,omitempty"`        // 下拉选项（旧版字段）
+	List         []ListOption   `json:"list,omitempty"`         // 下拉选项
+}
+
+// VariableEnum 变量下拉选项模型
+type VariableEnum struct {
+	Value string `json:"value"` // 选项值
+	Label string `json:"label"` // 选项标签
+}
+
+// options 返回变量的全部下拉选项值
+func (v VariableDef) options() []string {
+	var values []string
+	for _, e := range v.Enums {
+		values = append(values, e.Value)
+	}
+	for _, o := range v.List {
+		values = append(values, o.Value)
+	}
+	return values
+}
+
+// ValidateVariables 按应用配置校验对话变量
+//
+// 在发送ChatRequest之前检查必填变量是否提供、值类型是否匹配、文本长度和数字范围是否合法，
+// 以及下拉变量的值是否在选项中，避免工作流运行时才因变量错误失败。
+// 未在appVars中声明的变量不做校验。
+//
+// 参数：
+//
+//	appVars: 应用声明的变量，通常来自AppAPI.GetAppConfig返回的ChatConfig.Variables
+//	provided: 即将发送的ChatRequest.Variables
+//
+// 返回值：
+//
+//	error: 所有校验失败项合并后的错误，全部通过时返回nil
+//
+// 使用示例：
+//
+//	appInfo, _ := fgpt.App.GetAppConfig("your-app-id")
+//	if err := model.ValidateVariables(appInfo.ChatConfig.Variables, req.Variables); err != nil {
+//	    return err
+//	}
+func ValidateVariables(appVars []VariableDef, provided map[string]interface{}) error {
+	var errs []error
+	for _, def := range appVars {
+		value, ok := provided[def.Key]
+		if !ok || value == nil || value == "" {
+			if def.Required && def.DefaultValue == nil {
+				errs = append(errs, fmt.Errorf("变量 %s(%s) 为必填项", def.Key, def.Label))
+			}
+			continue
+		}
+
+		if err := validateVariable(def, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateVariable 校验单个变量的值
+func validateVariable(def VariableDef, value interface{}) error {
+	switch def.ValueType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("变量 %s 应为字符串，实际为 %T", def.Key, value)
+		}
+		if def.MaxLen > 0 && utf8.RuneCountInString(s) > def.MaxLen {
+			return fmt.Errorf("变量 %s 长度超过 %d", def.Key, def.MaxLen)
+		}
+	case "number":
+		n, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("变量 %s 应为数字，实际为 %T", def.Key, value)
+		}
+		if def.Min != nil && n < *def.Min {
+			return fmt.Errorf("变量 %s 不能小于 %v", def.Key, *def.Min)
+		}
+		if def.Max != nil && n > *def.Max {
+			return fmt.Errorf("变量 %s 不能大于 %v", def.Key, *def.Max)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("变量 %s 应为布尔值，实际为 %T", def.Key, value)
+		}
+	}
+
+	// 下拉变量的值必须在选项中
+	if options := def.options(); def.Type == "select" && len(options) > 0 {
+		s := fmt.Sprint(value)
+		for _, option := range options {
+			if option == s {
+				return nil
+			}
+		}
+		return fmt.Errorf("变量 %s 的值 %q 不在可选项 %v 中", def.Key, s, options)
+	}
+	return nil
+}
+
+// toFloat 将各种数字类型转换为float64
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// ErrVarNotFound 变量不存在或值为null
+var ErrVarNotFound = errors.New("变量不存在")
+
+// Vars 对话变量的读取辅助类型
+//
+// 与ChatRequest.Variables、updateVariables事件和ChatInitResponse.Variables使用相同的底层类型，
+// 可以直接转换使用，序列化结果不变。JSON中的数字解析后为float64，读取时按需转换，
+// 避免在各处重复编写类型断言。
+//
+// 使用示例：
+//
+//	vars := model.Vars(initResp.Variables)
+//	count, err := vars.Int("count")
+//	if errors.Is(err, model.ErrVarNotFound) {
+//	    count = 0 // 使用默认值
+//	}
+type Vars map[string]interface{}
+
+// Has 判断变量是否存在且不为null
+func (v Vars) Has(key string) bool {
+	return v[key] != nil
+}
+
+// String 读取字符串变量
+//
+// 数字和布尔值会被格式化为字符串，整数不带小数点。
+//
+// 参数：
+//
+//	key: 变量键
+//
+// 返回值：
+//
+//	string: 变量值
+//	error: 变量不存在时返回ErrVarNotFound，对象、数组等无法转换的类型返回错误
+func (v Vars) String(key string) (string, error) {
+	value, err := v.get(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch val := value.(type) {
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case json.Number:
+		return val.String(), nil
+	}
+	if n, ok := toFloat(value); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("变量 %s 无法转换为字符串，实际为 %T", key, value)
+}
+
+// Int 读取整数变量
+//
+// 支持各种数字类型以及内容为整数的字符串，带小数部分的数字会返回错误而不是被截断。
+//
+// 参数：
+//
+//	key: 变量键
+//
+// 返回值：
+//
+//	int: 变量值
+//	error: 变量不存在时返回ErrVarNotFound，无法转换为整数时返回错误
+func (v Vars) Int(key string) (int, error) {
+	n, err := v.Float(key)
+	if err != nil {
+		return 0, err
+	}
+	if n != math.Trunc(n) || n > math.MaxInt64 || n < math.MinInt64 {
+		return 0, fmt.Errorf("变量 %s 的值 %v 不是整数", key, n)
+	}
+	return int(n), nil
+}
+
+// Float 读取数字变量
+//
+// 支持各种数字类型以及内容为数字的字符串。
+//
+// 参数：
+//
+//	key: 变量键
+//
+// 返回值：
+//
+//	float64: 变量值
+//	error: 变量不存在时返回ErrVarNotFound，无法转换为数字时返回错误
+func (v Vars) Float(key string) (float64, error) {
+	value, err := v.get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	switch val := value.(type) {
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return 0, fmt.Errorf("变量 %s 的值 %q 不是数字", key, val)
+		}
+		return n, nil
+	case json.Number:
+		return val.Float64()
+	}
+	if n, ok := toFloat(value); ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("变量 %s 应为数字，实际为 %T", key, value)
+}
+
+// Bool 读取布尔变量
+//
+// 支持布尔值以及"true"、"false"、"1"、"0"等字符串。
+//
+// 参数：
+//
+//	key: 变量键
+//
+// 返回值：
+//
+//	bool: 变量值
+//	error: 变量不存在时返回ErrVarNotFound，无法转换为布尔值时返回错误
+func (v Vars) Bool(key string) (bool, error) {
+	value, err := v.get(key)
+	if err != nil {
+		return false, err
+	}
+
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return false, fmt.Errorf("变量 %s 的值 %q 不是布尔值", key, val)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("变量 %s 应为布尔值，实际为 %T", key, value)
+}
+
+// get 读取变量原始值，不存在或为null时返回ErrVarNotFound
+func (v Vars) get(key string) (interface{}, error) {
+	value := v[key]
+	if value == nil {
+		return nil, fmt.Errorf("%w: %s", ErrVarNotFound, key)
+	}
+	return value, nil
+}